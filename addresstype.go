@@ -0,0 +1,51 @@
+package melissa
+
+// Address types, by country, of each class of address.
+var (
+	poBoxTypes           = map[string][]string{"US": {"P"}, "CA": {"3", "D", "E"}}
+	ruralRouteTypes      = map[string][]string{"US": {"R"}, "CA": {"2", "4"}}
+	highriseTypes        = map[string][]string{"US": {"H"}, "CA": {"K"}}
+	generalDeliveryTypes = map[string][]string{"US": {"G"}, "CA": {"5", "L"}}
+)
+
+// AddressTypeDescription returns the description of the record's AddressType, using the
+// table of its country (AddressCodesUS or AddressCodesCA), or an empty string when unknown.
+func (r Record) AddressTypeDescription() string {
+	switch r.CountryISO3166_1_Alpha2 {
+	case "US":
+		return AddressCodesUS[r.AddressType]
+	case "CA":
+		return AddressCodesCA[r.AddressType]
+	}
+	return ""
+}
+
+// IsPOBox returns whether the address is a PO box (or a Canadian lock box).
+func (r Record) IsPOBox() bool {
+	return r.isType(poBoxTypes)
+}
+
+// IsRuralRoute returns whether the address is served by a rural route.
+func (r Record) IsRuralRoute() bool {
+	return r.isType(ruralRouteTypes)
+}
+
+// IsHighrise returns whether the address is a highrise, business complex or building.
+func (r Record) IsHighrise() bool {
+	return r.isType(highriseTypes)
+}
+
+// IsGeneralDelivery returns whether the address is general delivery, held at a post office.
+func (r Record) IsGeneralDelivery() bool {
+	return r.isType(generalDeliveryTypes)
+}
+
+// isType returns whether the record's AddressType is one of the `types` of its country.
+func (r Record) isType(types map[string][]string) bool {
+	for _, t := range types[r.CountryISO3166_1_Alpha2] {
+		if r.AddressType == t {
+			return r.AddressType != ""
+		}
+	}
+	return false
+}
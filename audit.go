@@ -0,0 +1,97 @@
+package melissa
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// AuditRecord is the proof of a single verification.
+type AuditRecord struct {
+	Time                  time.Time
+	TransmissionReference string
+	Input                 AddressRequest
+	Output                Record
+	Codes                 []string
+}
+
+// AuditStore persists audit records, eg. to retain proof of verification.
+// See the auditsql package for a database/sql implementation.
+type AuditStore interface {
+	Save(ctx context.Context, r AuditRecord) error
+}
+
+// NopAuditStore is an AuditStore discarding every record.
+type NopAuditStore struct{}
+
+// Save implements AuditStore.
+func (NopAuditStore) Save(ctx context.Context, r AuditRecord) error {
+	return nil
+}
+
+// WithAuditStore saves an AuditRecord of every record returned by the client, whether by
+// Verify, VerifyBatch, QueryContext, QueryBatch, QueryBatchFunc or Do, to `s`.
+// Failing to save a record fails the call, returning its response.
+func WithAuditStore(s AuditStore) Option {
+	return func(c *Client) {
+		c.auditStore = s
+	}
+}
+
+// auditResponse saves an audit record of each of the records of `resp`, returned for `req`.
+func (c Client) auditResponse(req *http.Request, resp Response) error {
+	if c.auditStore == nil || len(resp.Records) == 0 {
+		return nil
+	}
+	inputs, err := requestInputs(req)
+	if err != nil {
+		return err
+	}
+	return c.audit(req.Context(), resp.TransmissionReference, inputsByID(inputs), resp.Records)
+}
+
+// requestInputs returns the addresses sent by `req`, re-reading the body of POST requests.
+func requestInputs(req *http.Request) ([]AddressRequest, error) {
+	if req.Method != "GET" {
+		if req.GetBody == nil {
+			return nil, nil
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	_, inputs, err := ParseRequest(req)
+	return inputs, err
+}
+
+// audit saves an audit record of each of the `records`, along with its input within `inputs`
+// matched by RecordID.
+func (c Client) audit(ctx context.Context, ref string, inputs map[string]AddressRequest, records []Record) error {
+	now := time.Now()
+	for _, rec := range records {
+		in, ok := inputs[rec.RecordID]
+		if !ok && len(inputs) == 1 {
+			for _, only := range inputs {
+				in = only
+			}
+		}
+		r := AuditRecord{now, ref, in, rec, rec.Codes()}
+		if err := c.auditStore.Save(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inputsByID returns the addresses `rs` by RecordID.
+func inputsByID(rs []AddressRequest) map[string]AddressRequest {
+	byID := make(map[string]AddressRequest, len(rs))
+	for _, r := range rs {
+		byID[r.RecordID] = r
+	}
+	return byID
+}
@@ -0,0 +1,70 @@
+// Package auditsql is a database/sql implementation of melissa.AuditStore.
+//
+// Records are inserted into a table created, for example, as:
+//
+//	CREATE TABLE melissa_audit (
+//		created_at             TIMESTAMP NOT NULL,
+//		transmission_reference VARCHAR(64) NOT NULL,
+//		record_id              VARCHAR(64) NOT NULL,
+//		codes                  VARCHAR(255) NOT NULL,
+//		input                  TEXT NOT NULL,
+//		output                 TEXT NOT NULL
+//	);
+//
+// The input and output are stored as JSON.
+package auditsql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/juztin/melissa"
+)
+
+// Store is a melissa.AuditStore inserting records into a SQL table.
+type Store struct {
+	db    *sql.DB
+	query string
+}
+
+// Save implements melissa.AuditStore.
+func (s *Store) Save(ctx context.Context, r melissa.AuditRecord) error {
+	input, err := json.Marshal(r.Input)
+	if err != nil {
+		return err
+	}
+	output, err := json.Marshal(r.Output)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, s.query,
+		r.Time.UTC(), r.TransmissionReference, r.Output.RecordID, strings.Join(r.Codes, ","),
+		string(input), string(output))
+	return err
+}
+
+// New returns a Store inserting into `table` of `db`, using "?" placeholders.
+func New(db *sql.DB, table string) *Store {
+	return newStore(db, table, func(int) string { return "?" })
+}
+
+// NewPostgres is like New, using PostgreSQL's "$1" style placeholders.
+func NewPostgres(db *sql.DB, table string) *Store {
+	return newStore(db, table, func(i int) string { return fmt.Sprintf("$%d", i) })
+}
+
+func newStore(db *sql.DB, table string, placeholder func(int) string) *Store {
+	ps := make([]string, 6)
+	for i := range ps {
+		ps[i] = placeholder(i + 1)
+	}
+	query := fmt.Sprintf(
+		"INSERT INTO %s (created_at, transmission_reference, record_id, codes, input, output) VALUES (%s)",
+		table, strings.Join(ps, ", "))
+	return &Store{db, query}
+}
+
+var _ melissa.AuditStore = (*Store)(nil)
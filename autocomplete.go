@@ -0,0 +1,34 @@
+package melissa
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+const expressEntryURL = "https://expressentry.melissadata.net/web/GlobalExpressFreeForm"
+
+// Autocomplete suggests up to `max` complete addresses, within `country`, for the partial
+// free-form `query` (eg. "22382 Avenida Em") using Melissa Data's Express Entry service.
+// The service's default number of suggestions is returned when `max` is zero.
+func (c Client) Autocomplete(ctx context.Context, query, country string, max int) ([]string, error) {
+	qs := url.Values{"ff": {query}, "country": {country}}
+	if max > 0 {
+		qs.Set("maxrecords", strconv.Itoa(max))
+	}
+	var resp struct {
+		Results []struct {
+			Address struct{ Address string }
+		}
+	}
+	if err := c.Get(ctx, c.URL(ServiceExpressEntry, expressEntryURL), qs, &resp); err != nil {
+		return nil, err
+	}
+	suggestions := make([]string, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		if r.Address.Address != "" {
+			suggestions = append(suggestions, r.Address.Address)
+		}
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,325 @@
+// Package batch verifies addresses in bulk using a melissa.Verifier,
+// chunking the input into batch requests which are verified concurrently.
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juztin/melissa"
+)
+
+// Row is a single input record.
+type Row struct {
+	// Request is the address to verify.
+	Request melissa.AddressRequest
+	// Fields are the original input fields, passed through to the output.
+	Fields []string
+	// Object is the original JSON input object, passed through to the output.
+	Object map[string]json.RawMessage
+	// Line is the one-based line of the input the row starts on, zero when unknown.
+	Line int
+	// Raw is the unparsed input of a row which failed to parse.
+	Raw string
+}
+
+// Source provides the rows to verify, returning io.EOF once exhausted.
+type Source interface {
+	Next() (Row, error)
+}
+
+// Sink receives the result of each verified row, in input order.
+type Sink interface {
+	Write(row Row, res melissa.Result) error
+}
+
+// Summary is a tally of the rows verified by a run.
+type Summary struct {
+	Total    int
+	Outcomes map[melissa.Outcome]int
+	// Errors are the rows which failed, including those verified with an AE code.
+	Errors ErrorReport
+}
+
+func (s *Summary) add(row Row, res melissa.Result) {
+	if s.Outcomes == nil {
+		s.Outcomes = map[melissa.Outcome]int{}
+	}
+	s.Total++
+	s.Outcomes[res.Outcome]++
+	if len(res.Errors) > 0 {
+		s.Errors.add(Failure{Row: row, Code: res.Errors[0]})
+	}
+}
+
+// ErrInvalidOption is returned by runs of a Verifier configured with an invalid chunk size or concurrency.
+var ErrInvalidOption = errors.New("invalid batch option, chunk size and concurrency must be at least 1")
+
+// Option configures a Verifier.
+type Option func(*Verifier)
+
+// WithChunkSize sends at most `n` rows per batch request, defaulting to melissa.MaxRecords.
+// Runs fail with ErrInvalidOption when `n` is less than 1.
+func WithChunkSize(n int) Option {
+	return func(v *Verifier) {
+		v.chunkSize = n
+	}
+}
+
+// WithConcurrency verifies up to `n` chunks at once, defaulting to 4.
+// Runs fail with ErrInvalidOption when `n` is less than 1.
+func WithConcurrency(n int) Option {
+	return func(v *Verifier) {
+		v.workers = n
+	}
+}
+
+// WithMinGeocodeLevel clears the latitude and longitude of results geocoded less
+// precisely than `l`, so that (eg.) ZIP centroids are never mistaken for rooftop points.
+func WithMinGeocodeLevel(l melissa.GeocodeLevel) Option {
+	return func(v *Verifier) {
+		v.minGeocode = l
+	}
+}
+
+// Verifier verifies rows in bulk.
+type Verifier struct {
+	verifier   melissa.Verifier
+	chunkSize  int
+	workers    int
+	deadLetter DeadLetter
+	minGeocode melissa.GeocodeLevel
+	notifiers  []Notifier
+}
+
+// chunk is a sequenced group of rows verified within a single batch request.
+type chunk struct {
+	seq     int
+	rows    []Row
+	results []melissa.Result
+	err     error
+	// failures are the rows which failed to parse while reading the chunk.
+	failures []Failure
+}
+
+// Run verifies every row from `src`, writing each result to `dst` in input order.
+// The run stops at the first error encountered, or when `ctx` is done, returning its error.
+// Call options attached to `ctx` using melissa.ContextWithCallOptions (eg. WithCost)
+// apply to every batch request.
+func (v *Verifier) Run(ctx context.Context, src Source, dst Sink) (Summary, error) {
+	started := time.Now()
+	sum, err := v.run(ctx, src, dst, nil)
+	return sum, v.notify(ctx, "", started, sum, err)
+}
+
+// run is Run, calling `failed`, when not nil, with the rows of the chunk which failed.
+func (v *Verifier) run(ctx context.Context, src Source, dst Sink, failed func([]Row, error)) (Summary, error) {
+	if v.chunkSize < 1 || v.workers < 1 {
+		return Summary{}, ErrInvalidOption
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Read the source into chunks. readErr is only read once readDone is closed.
+	var readErr error
+	chunks := make(chan *chunk)
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		defer close(chunks)
+		for seq := 0; ; seq++ {
+			rows, failures, err := v.read(src)
+			if len(rows) > 0 || len(failures) > 0 {
+				select {
+				case chunks <- &chunk{seq: seq, rows: rows, failures: failures}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+
+	// Verify chunks concurrently.
+	var wg sync.WaitGroup
+	done := make(chan *chunk)
+	for i := 0; i < v.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				if len(c.rows) > 0 {
+					c.results, c.err = v.verify(ctx, c.rows)
+				}
+				select {
+				case done <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Write results, re-ordering chunks back into input order.
+	var sum Summary
+	var err error
+	pending := map[int]*chunk{}
+	next := 0
+	for c := range done {
+		if err != nil {
+			continue
+		}
+		pending[c.seq] = c
+		for p, ok := pending[next]; ok && err == nil; p, ok = pending[next] {
+			delete(pending, next)
+			next++
+			if err = v.deadLetterAll(p.failures, &sum); err != nil {
+				break
+			}
+			if err = p.err; err == nil {
+				err = v.write(dst, p, &sum)
+				continue
+			}
+			if failed != nil {
+				failed(p.rows, err)
+			}
+			if v.deadLetter != nil && ctx.Err() == nil {
+				fs := make([]Failure, len(p.rows))
+				for i, row := range p.rows {
+					fs[i] = Failure{row, failureCode(p.err), p.err}
+				}
+				err = v.deadLetterAll(fs, &sum)
+			}
+		}
+		if err != nil {
+			cancel()
+		}
+	}
+	// Chunks are dropped once the context is done, so the run is incomplete.
+	ctxErr := ctx.Err()
+	// Workers may stop once the context is done, while the source is still being read,
+	// so wait for the reader to stop using the source before returning.
+	cancel()
+	<-readDone
+	if err == nil {
+		err = readErr
+	}
+	if err == nil {
+		err = ctxErr
+	}
+	return sum, err
+}
+
+// read returns up to the chunk size of rows from `src`, along with the rows which
+// failed to parse when dead-lettering.
+func (v *Verifier) read(src Source) ([]Row, []Failure, error) {
+	var rows []Row
+	var failures []Failure
+	for len(rows) < v.chunkSize {
+		row, err := src.Next()
+		if err != nil && v.deadLetter != nil && skippable(err) {
+			failures = append(failures, Failure{row, CodeParse, err})
+			continue
+		} else if err != nil {
+			return rows, failures, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, failures, nil
+}
+
+// verify verifies the given `rows` within a single batch, returning a result for each row.
+func (v *Verifier) verify(ctx context.Context, rows []Row) ([]melissa.Result, error) {
+	// Records are identified by their position, to match results back to rows.
+	reqs := make([]melissa.AddressRequest, len(rows))
+	for i, row := range rows {
+		reqs[i] = row.Request
+		reqs[i].RecordID = strconv.Itoa(i + 1)
+	}
+	rs, err := v.verifier.VerifyBatch(ctx, reqs)
+	var pe *melissa.PartialError
+	if err != nil && !errors.As(err, &pe) {
+		return nil, err
+	}
+	byID := make(map[string]melissa.Result, len(rs))
+	for _, r := range rs {
+		byID[r.RecordID] = r
+	}
+	if pe != nil {
+		// Failed records are written with their codes.
+		for _, f := range pe.Failures {
+			byID[f.RecordID] = melissa.NewResult(melissa.Record{RecordID: f.RecordID, Results: strings.Join(f.Codes, ",")})
+		}
+	}
+
+	results := make([]melissa.Result, len(rows))
+	for i, row := range rows {
+		r, ok := byID[reqs[i].RecordID]
+		if !ok {
+			r = melissa.NewResult(melissa.Record{})
+		}
+		r.RecordID = row.Request.RecordID
+		if r.GeocodeLevel() < v.minGeocode {
+			r.Latitude, r.Longitude = "", ""
+		}
+		results[i] = r
+	}
+	return results, nil
+}
+
+// write writes the results of chunk `c` to `dst`, tallying them within `sum`.
+// Rows verified with an AE code are also dead-lettered.
+func (v *Verifier) write(dst Sink, c *chunk, sum *Summary) error {
+	for i, row := range c.rows {
+		res := c.results[i]
+		if err := dst.Write(row, res); err != nil {
+			return err
+		}
+		sum.add(row, res)
+		if v.deadLetter != nil && len(res.Errors) > 0 {
+			if err := v.deadLetter.WriteFailure(Failure{Row: row, Code: res.Errors[0]}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deadLetterAll records the failures `fs` within `sum`, writing them to the dead-letter.
+func (v *Verifier) deadLetterAll(fs []Failure, sum *Summary) error {
+	for _, f := range fs {
+		sum.Errors.add(f)
+		if err := v.deadLetter.WriteFailure(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// New returns a bulk Verifier which verifies addresses using `v`, configured by the given `opts`.
+func New(v melissa.Verifier, opts ...Option) *Verifier {
+	b := &Verifier{
+		verifier:  v,
+		chunkSize: melissa.MaxRecords,
+		workers:   4,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
@@ -0,0 +1,211 @@
+package batch
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/juztin/melissa"
+)
+
+// Mapping maps melissa.AddressRequest field names to the CSV column holding their value.
+type Mapping map[string]string
+
+// DefaultMapping maps each melissa.AddressRequest string field to the column of the same name.
+var DefaultMapping = func() Mapping {
+	m := Mapping{}
+	t := reflect.TypeOf(melissa.AddressRequest{})
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type.Kind() == reflect.String {
+			m[t.Field(i).Name] = t.Field(i).Name
+		}
+	}
+	return m
+}()
+
+// mappedField returns the index of the AddressRequest string `field`.
+func mappedField(field string) (int, error) {
+	f, ok := reflect.TypeOf(melissa.AddressRequest{}).FieldByName(field)
+	if !ok || f.Type.Kind() != reflect.String {
+		return 0, fmt.Errorf("invalid mapping, unknown field %q", field)
+	}
+	return f.Index[0], nil
+}
+
+// OutputColumns are the columns appended to each output row by a CSVWriter.
+var OutputColumns = []string{
+	"Status",
+	"Results",
+	"Corrections",
+	"AddressLine1",
+	"AddressLine2",
+	"Locality",
+	"AdministrativeArea",
+	"PostalCode",
+	"Country",
+	"Latitude",
+	"Longitude",
+}
+
+// CSVReader is a Source reading rows from CSV with a header row.
+type CSVReader struct {
+	r      *csv.Reader
+	raw    *rawReader
+	header []string
+	// columns maps AddressRequest field indexes to column indexes.
+	columns map[int]int
+}
+
+// Header returns the header row of the input.
+func (r *CSVReader) Header() []string {
+	return r.header
+}
+
+// Next implements Source. Rows which fail to parse are returned along with the error,
+// holding their line and raw input.
+func (r *CSVReader) Next() (Row, error) {
+	fields, err := r.r.Read()
+	raw := r.raw.next(r.r.InputOffset())
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return Row{Line: pe.StartLine, Raw: strings.TrimRight(raw, "\r\n")}, err
+	} else if err != nil {
+		return Row{}, err
+	}
+	line, _ := r.r.FieldPos(0)
+	row := Row{Fields: fields, Line: line}
+	v := reflect.ValueOf(&row.Request).Elem()
+	for f, col := range r.columns {
+		if col < len(fields) {
+			v.Field(f).SetString(strings.TrimSpace(fields[col]))
+		}
+	}
+	return row, nil
+}
+
+// NewCSVReader returns a CSVReader reading from `r`, mapping columns to address
+// fields using `m`. Mapped columns missing from the header are ignored.
+func NewCSVReader(r io.Reader, m Mapping) (*CSVReader, error) {
+	raw := &rawReader{r: r}
+	cr := csv.NewReader(raw)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	raw.next(cr.InputOffset())
+
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.TrimSpace(h)] = i
+	}
+	columns := map[int]int{}
+	for field, col := range m {
+		f, err := mappedField(field)
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := cols[col]; ok {
+			columns[f] = i
+		}
+	}
+	return &CSVReader{cr, raw, header, columns}, nil
+}
+
+// rawReader is an io.Reader retaining the input read ahead of the last record.
+type rawReader struct {
+	r   io.Reader
+	buf []byte
+	// offset is the input offset of the start of buf.
+	offset int64
+}
+
+func (r *rawReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.buf = append(r.buf, p[:n]...)
+	return n, err
+}
+
+// next returns the input up to `offset`, the end of the last record, discarding it.
+func (r *rawReader) next(offset int64) string {
+	n := int(offset - r.offset)
+	if n <= 0 || n > len(r.buf) {
+		return ""
+	}
+	raw := string(r.buf[:n])
+	r.buf = append(r.buf[:0], r.buf[n:]...)
+	r.offset = offset
+	return raw
+}
+
+// CSVWriter is a Sink writing each row, followed by the OutputColumns, as CSV.
+type CSVWriter struct {
+	w       *csv.Writer
+	header  []string
+	columns []string
+}
+
+// Write implements Sink.
+func (w *CSVWriter) Write(row Row, res melissa.Result) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	fields := append(append([]string(nil), row.Fields...),
+		res.Outcome.String(),
+		res.Results,
+		strings.Join(res.Corrections, ","),
+		res.AddressLine1,
+		res.AddressLine2,
+		res.Locality,
+		res.AdministrativeArea,
+		res.PostalCode,
+		res.CountryISO3166_1_Alpha2,
+		res.Latitude,
+		res.Longitude,
+	)
+	return w.w.Write(fields)
+}
+
+// writeHeader writes the header row, if it hasn't been written yet.
+func (w *CSVWriter) writeHeader() error {
+	if w.header == nil {
+		return nil
+	}
+	err := w.w.Write(append(append([]string(nil), w.header...), w.columns...))
+	w.header = nil
+	return err
+}
+
+// Flush writes any buffered data, including the header when no rows were written,
+// returning any error that occurred.
+func (w *CSVWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// NewCSVWriter returns a CSVWriter writing to `w`, prefixed by the `header` row.
+func NewCSVWriter(w io.Writer, header []string) *CSVWriter {
+	return &CSVWriter{csv.NewWriter(w), header, OutputColumns}
+}
+
+// ProcessCSV verifies every row of the CSV read from `r`, mapping columns using `m`,
+// and writes each row, along with its verification result, as CSV to `w`.
+func (v *Verifier) ProcessCSV(ctx context.Context, r io.Reader, w io.Writer, m Mapping) (Summary, error) {
+	src, err := NewCSVReader(r, m)
+	if err != nil {
+		return Summary{}, err
+	}
+	dst := NewCSVWriter(w, src.Header())
+	sum, err := v.Run(ctx, src, dst)
+	if ferr := dst.Flush(); err == nil {
+		err = ferr
+	}
+	return sum, err
+}
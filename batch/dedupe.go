@@ -0,0 +1,47 @@
+package batch
+
+import "github.com/juztin/melissa"
+
+// Cluster is a group of rows resolving to the same address.
+type Cluster struct {
+	Key  string
+	Rows []Row
+}
+
+// Deduper is a Sink grouping rows by the melissa.Record.DedupeKey of their results,
+// passing each row through to another Sink.
+type Deduper struct {
+	dst  Sink
+	keys []string
+	rows map[string][]Row
+}
+
+// Write implements Sink.
+func (d *Deduper) Write(row Row, res melissa.Result) error {
+	if key := res.DedupeKey(); key != "" {
+		if _, ok := d.rows[key]; !ok {
+			d.keys = append(d.keys, key)
+		}
+		d.rows[key] = append(d.rows[key], row)
+	}
+	if d.dst == nil {
+		return nil
+	}
+	return d.dst.Write(row, res)
+}
+
+// Duplicates returns the clusters of more than one row, in order of their first row.
+func (d *Deduper) Duplicates() []Cluster {
+	var cs []Cluster
+	for _, key := range d.keys {
+		if rows := d.rows[key]; len(rows) > 1 {
+			cs = append(cs, Cluster{key, rows})
+		}
+	}
+	return cs
+}
+
+// NewDeduper returns a Deduper passing rows through to `dst`, which may be nil.
+func NewDeduper(dst Sink) *Deduper {
+	return &Deduper{dst: dst, rows: map[string][]Row{}}
+}
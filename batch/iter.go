@@ -0,0 +1,53 @@
+package batch
+
+import (
+	"context"
+	"iter"
+
+	"github.com/juztin/melissa"
+)
+
+// Results verifies every row from `src`, yielding each result in input order as its chunk
+// completes, so results needn't be held in memory. Iteration stops after yielding the
+// first error; breaking out of the loop stops the run.
+func (v *Verifier) Results(ctx context.Context, src Source) iter.Seq2[melissa.Result, error] {
+	return func(yield func(melissa.Result, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := make(chan melissa.Result)
+		errc := make(chan error, 1)
+		go func() {
+			_, err := v.Run(ctx, src, chanSink{ctx, results})
+			close(results)
+			errc <- err
+		}()
+
+		for res := range results {
+			if !yield(res, nil) {
+				cancel()
+				for range results {
+				}
+				return
+			}
+		}
+		if err := <-errc; err != nil {
+			yield(melissa.Result{}, err)
+		}
+	}
+}
+
+// chanSink is a Sink sending each result to a channel.
+type chanSink struct {
+	ctx context.Context
+	ch  chan<- melissa.Result
+}
+
+func (s chanSink) Write(row Row, res melissa.Result) error {
+	select {
+	case s.ch <- res:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
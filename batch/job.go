@@ -0,0 +1,167 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/juztin/melissa"
+)
+
+// Checkpoint is the progress of a Job.
+type Checkpoint struct {
+	// Processed are the IDs of the rows whose results were written.
+	Processed map[string]bool
+	// Failed maps the IDs of rows which failed to verify to their error.
+	Failed map[string]string
+}
+
+// Store persists Job checkpoints.
+type Store interface {
+	// Load returns the checkpoint of job `id`, or an empty checkpoint when there isn't one.
+	Load(ctx context.Context, id string) (Checkpoint, error)
+	// Save stores the checkpoint of job `id`.
+	Save(ctx context.Context, id string, cp Checkpoint) error
+}
+
+// Job is a resumable bulk run, checkpointing its progress to a Store so that a run
+// restarted after a crash doesn't re-verify (and re-pay for) already processed rows.
+//
+// Rows are identified by their Request.RecordID, or their one-based position within
+// the source when empty, so a resumed job must read the same source from the start.
+type Job struct {
+	ID       string
+	Verifier *Verifier
+	Store    Store
+}
+
+// Run verifies every row from `src` not processed by a previous run of the job, writing
+// each result to `dst` in input order. Previously processed rows aren't written to `dst`.
+// The returned Summary only tallies the rows verified by this run.
+func (j *Job) Run(ctx context.Context, src Source, dst Sink) (Summary, error) {
+	started := time.Now()
+	cp, err := j.Store.Load(ctx, j.ID)
+	if err != nil {
+		return Summary{}, err
+	}
+	if cp.Processed == nil {
+		cp.Processed = map[string]bool{}
+	}
+	if cp.Failed == nil {
+		cp.Failed = map[string]string{}
+	}
+
+	// The source is read concurrently with writes, so it skips rows using a copy.
+	skip := make(map[string]bool, len(cp.Processed))
+	for id := range cp.Processed {
+		skip[id] = true
+	}
+	js := &jobSink{job: j, ctx: ctx, dst: dst, cp: &cp}
+	sum, err := j.Verifier.run(ctx, &jobSource{src: src, skip: skip}, js, func(rows []Row, err error) {
+		for _, row := range rows {
+			cp.Failed[row.Request.RecordID] = err.Error()
+		}
+	})
+	if serr := j.Store.Save(ctx, j.ID, cp); err == nil {
+		err = serr
+	}
+	return sum, j.Verifier.notify(ctx, j.ID, started, sum, err)
+}
+
+// jobSource skips rows processed by a previous run, assigning IDs to those without one.
+type jobSource struct {
+	src  Source
+	skip map[string]bool
+	n    int
+}
+
+func (s *jobSource) Next() (Row, error) {
+	for {
+		row, err := s.src.Next()
+		if err != nil {
+			return row, err
+		}
+		s.n++
+		if row.Request.RecordID == "" {
+			row.Request.RecordID = strconv.Itoa(s.n)
+		}
+		if !s.skip[row.Request.RecordID] {
+			return row, nil
+		}
+	}
+}
+
+// jobSink marks written rows as processed, saving the checkpoint after every chunk.
+type jobSink struct {
+	job     *Job
+	ctx     context.Context
+	dst     Sink
+	cp      *Checkpoint
+	written int
+}
+
+func (s *jobSink) Write(row Row, res melissa.Result) error {
+	if err := s.dst.Write(row, res); err != nil {
+		return err
+	}
+	s.cp.Processed[row.Request.RecordID] = true
+	delete(s.cp.Failed, row.Request.RecordID)
+	if s.written++; s.written%s.job.Verifier.chunkSize == 0 {
+		return s.job.Store.Save(s.ctx, s.job.ID, *s.cp)
+	}
+	return nil
+}
+
+// FileStore is a Store persisting each checkpoint as a JSON file within a directory.
+type FileStore string
+
+// NewFileStore returns a FileStore storing checkpoints within `dir`, created when missing.
+func NewFileStore(dir string) FileStore {
+	return FileStore(dir)
+}
+
+// Load implements Store.
+func (s FileStore) Load(ctx context.Context, id string) (Checkpoint, error) {
+	var cp Checkpoint
+	data, err := ioutil.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return cp, nil
+	} else if err != nil {
+		return cp, err
+	}
+	err = json.Unmarshal(data, &cp)
+	return cp, err
+}
+
+// Save implements Store, replacing the file atomically.
+func (s FileStore) Save(ctx context.Context, id string, cp Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(string(s), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(string(s), id+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(data); err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path(id))
+}
+
+func (s FileStore) path(id string) string {
+	return filepath.Join(string(s), id+".json")
+}
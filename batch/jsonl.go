@@ -0,0 +1,162 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/juztin/melissa"
+)
+
+// ResultKey is the key of the verification result within each JSONL output object.
+const ResultKey = "Result"
+
+// Keys added to each object written by a JSONLDeadLetter.
+const (
+	// FailureKey is the key of the code and error of the failure.
+	FailureKey = "Failure"
+	// RawKey is the key of the raw input of a row which failed to parse.
+	RawKey = "Raw"
+)
+
+// JSONLReader is a Source reading one JSON object per line.
+type JSONLReader struct {
+	s *bufio.Scanner
+	// fields maps object keys to AddressRequest field indexes.
+	fields map[string]int
+	line   int
+}
+
+// Next implements Source. Every field of the object is kept within Row.Object,
+// to be passed through to the output. Lines which fail to parse are returned along
+// with the error, holding their line number and raw input.
+func (r *JSONLReader) Next() (Row, error) {
+	for r.s.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.s.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return Row{Line: r.line, Raw: string(data)}, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		row := Row{Object: obj, Line: r.line}
+		v := reflect.ValueOf(&row.Request).Elem()
+		for key, raw := range obj {
+			f, ok := r.fields[key]
+			if !ok {
+				continue
+			}
+			var s string
+			if json.Unmarshal(raw, &s) != nil {
+				s = string(raw)
+			}
+			v.Field(f).SetString(s)
+		}
+		return row, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return Row{}, err
+	}
+	return Row{}, io.EOF
+}
+
+// NewJSONLReader returns a JSONLReader reading from `r`, mapping object keys to address
+// fields using `m`.
+func NewJSONLReader(r io.Reader, m Mapping) (*JSONLReader, error) {
+	fields := map[string]int{}
+	for field, key := range m {
+		f, err := mappedField(field)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = f
+	}
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	return &JSONLReader{s: s, fields: fields}, nil
+}
+
+// JSONLWriter is a Sink writing one JSON object per line, holding the fields of the input
+// object along with the result under ResultKey.
+type JSONLWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// Write implements Sink.
+func (w *JSONLWriter) Write(row Row, res melissa.Result) error {
+	out := make(map[string]interface{}, len(row.Object)+1)
+	for k, v := range row.Object {
+		out[k] = v
+	}
+	out[ResultKey] = res
+	return w.enc.Encode(out)
+}
+
+// Flush writes any buffered data.
+func (w *JSONLWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// NewJSONLWriter returns a JSONLWriter writing to `w`.
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	bw := bufio.NewWriter(w)
+	return &JSONLWriter{bw, json.NewEncoder(bw)}
+}
+
+// JSONLDeadLetter is a DeadLetter writing each failed row as a JSON object per line,
+// holding the fields of the input object along with its code and error under FailureKey.
+// Rows which failed to parse are written with their raw input under RawKey.
+type JSONLDeadLetter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// WriteFailure implements DeadLetter.
+func (d *JSONLDeadLetter) WriteFailure(f Failure) error {
+	out := make(map[string]interface{}, len(f.Row.Object)+1)
+	for k, v := range f.Row.Object {
+		out[k] = v
+	}
+	if f.Row.Object == nil {
+		out[RawKey] = f.Row.Raw
+	}
+	msg := ""
+	if f.Err != nil {
+		msg = f.Err.Error()
+	}
+	out[FailureKey] = struct{ Code, Error string }{f.Code, msg}
+	return d.enc.Encode(out)
+}
+
+// Flush writes any buffered data.
+func (d *JSONLDeadLetter) Flush() error {
+	return d.w.Flush()
+}
+
+// NewJSONLDeadLetter returns a JSONLDeadLetter writing to `w`.
+func NewJSONLDeadLetter(w io.Writer) *JSONLDeadLetter {
+	bw := bufio.NewWriter(w)
+	return &JSONLDeadLetter{bw, json.NewEncoder(bw)}
+}
+
+// ProcessJSONL verifies every object of the JSONL read from `r`, mapping keys using `m`,
+// and writes each object, along with its verification result, as JSONL to `w`.
+func (v *Verifier) ProcessJSONL(ctx context.Context, r io.Reader, w io.Writer, m Mapping) (Summary, error) {
+	src, err := NewJSONLReader(r, m)
+	if err != nil {
+		return Summary{}, err
+	}
+	dst := NewJSONLWriter(w)
+	sum, err := v.Run(ctx, src, dst)
+	if ferr := dst.Flush(); err == nil {
+		err = ferr
+	}
+	return sum, err
+}
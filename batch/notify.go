@@ -0,0 +1,134 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juztin/melissa"
+)
+
+// Notification describes a completed run.
+type Notification struct {
+	// JobID is the ID of the Job, empty for runs of a Verifier.
+	JobID    string
+	Summary  Summary
+	Err      error
+	Started  time.Time
+	Finished time.Time
+}
+
+// Notifier is notified when a run completes.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// WithNotifier notifies `n` when each run completes, whether or not it succeeded.
+func WithNotifier(n Notifier) Option {
+	return func(v *Verifier) {
+		v.notifiers = append(v.notifiers, n)
+	}
+}
+
+// notify notifies the verifier's notifiers of the run of job `id`, returning `err`, or
+// the first notification error when the run succeeded.
+func (v *Verifier) notify(ctx context.Context, id string, started time.Time, sum Summary, err error) error {
+	n := Notification{id, sum, err, started, time.Now()}
+	for _, nt := range v.notifiers {
+		if nerr := nt.Notify(ctx, n); err == nil && nerr != nil {
+			err = nerr
+		}
+	}
+	return err
+}
+
+// webhookPayload is the JSON body sent by a WebhookNotifier.
+type webhookPayload struct {
+	JobID       string                  `json:",omitempty"`
+	Total       int                     `json:"total"`
+	Outcomes    map[melissa.Outcome]int `json:"outcomes"`
+	ErrorCounts map[string]int          `json:"errorCounts,omitempty"`
+	Error       string                  `json:"error,omitempty"`
+	Started     time.Time               `json:"started"`
+	Finished    time.Time               `json:"finished"`
+}
+
+// SignatureHeader is the header holding the hex encoded HMAC-SHA256 signature of a webhook
+// body, prefixed with "sha256=".
+const SignatureHeader = "X-Melissa-Signature"
+
+// WebhookNotifier is a Notifier POSTing a JSON summary of each run to a URL, signed using
+// HMAC-SHA256 so the receiver can verify it.
+type WebhookNotifier struct {
+	URL    string
+	Secret []byte
+	// Client is used to send the webhook, defaulting to http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify implements Notifier.
+func (w WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	p := webhookPayload{
+		JobID:       n.JobID,
+		Total:       n.Summary.Total,
+		Outcomes:    n.Summary.Outcomes,
+		ErrorCounts: n.Summary.Errors.Counts,
+		Started:     n.Started,
+		Finished:    n.Finished,
+	}
+	if n.Err != nil {
+		p.Error = n.Err.Error()
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, "sha256="+Sign(w.Secret, body))
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("invalid response code, %d, received for webhook", resp.StatusCode)
+	}
+	return nil
+}
+
+// NewWebhookNotifier returns a WebhookNotifier POSTing to `url`, signed using `secret`.
+func NewWebhookNotifier(url string, secret []byte) WebhookNotifier {
+	return WebhookNotifier{URL: url, Secret: secret}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of `body` using `secret`.
+func Sign(secret, body []byte) string {
+	m := hmac.New(sha256.New, secret)
+	m.Write(body)
+	return hex.EncodeToString(m.Sum(nil))
+}
+
+// VerifySignature reports whether `signature`, the value of the SignatureHeader, is the
+// valid signature of `body` using `secret`.
+func VerifySignature(secret, body []byte, signature string) bool {
+	want := "sha256=" + Sign(secret, body)
+	return hmac.Equal([]byte(signature), []byte(want))
+}
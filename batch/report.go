@@ -0,0 +1,125 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juztin/melissa"
+)
+
+// Failure codes of rows which failed without a record level (AE) code.
+const (
+	CodeParse     = "parse"
+	CodeTransient = "transient"
+	CodeError     = "error"
+)
+
+// Failure is a row which failed to verify.
+type Failure struct {
+	Row Row
+	// Code is the AE code of the record, an HTTP status or transmission code, or one
+	// of CodeParse, CodeTransient or CodeError.
+	Code string
+	// Err is the error the row failed with, nil for rows failing with an AE code.
+	Err error
+}
+
+// ErrorReport aggregates the failures of a run.
+type ErrorReport struct {
+	// Counts are the number of failures by code.
+	Counts   map[string]int
+	Failures []Failure
+}
+
+func (r *ErrorReport) add(f Failure) {
+	if r.Counts == nil {
+		r.Counts = map[string]int{}
+	}
+	r.Counts[f.Code]++
+	r.Failures = append(r.Failures, f)
+}
+
+// DeadLetter receives the rows which failed to verify, so they can be re-queued.
+type DeadLetter interface {
+	WriteFailure(f Failure) error
+}
+
+// WithDeadLetter continues the run past rows which fail to parse and chunks which fail
+// to verify (once any retries are exhausted), writing their rows to `dl`.
+// Rows verified with an AE code are also written to `dl`.
+func WithDeadLetter(dl DeadLetter) Option {
+	return func(v *Verifier) {
+		v.deadLetter = dl
+	}
+}
+
+// failureCode returns the code classifying `err`.
+func failureCode(err error) string {
+	var se melissa.StatusError
+	var te melissa.TransmissionError
+	switch {
+	case errors.As(err, &te):
+		return strings.Join(te.Codes, ",")
+	case errors.As(err, &se):
+		return strconv.Itoa(se.StatusCode)
+	case skippable(err):
+		return CodeParse
+	case melissa.Unavailable(err):
+		return CodeTransient
+	}
+	return CodeError
+}
+
+// skippable returns whether a source may continue past the row failing with `err`.
+func skippable(err error) bool {
+	var pe *csv.ParseError
+	var se *json.SyntaxError
+	var te *json.UnmarshalTypeError
+	return errors.As(err, &pe) || errors.As(err, &se) || errors.As(err, &te)
+}
+
+// CSVDeadLetter is a DeadLetter writing the fields of each failed row, followed by
+// its code and error, as CSV. Rows which failed to parse are written as their raw input.
+// Rows read as JSON objects, which have no fields, are written as the JSON object;
+// use a JSONLDeadLetter for JSONL input.
+type CSVDeadLetter struct {
+	w *CSVWriter
+}
+
+// WriteFailure implements DeadLetter.
+func (d *CSVDeadLetter) WriteFailure(f Failure) error {
+	if err := d.w.writeHeader(); err != nil {
+		return err
+	}
+	msg := ""
+	if f.Err != nil {
+		msg = f.Err.Error()
+	}
+	fields := f.Row.Fields
+	if f.Row.Raw != "" {
+		fields = []string{f.Row.Raw}
+	} else if fields == nil && f.Row.Object != nil {
+		obj, err := json.Marshal(f.Row.Object)
+		if err != nil {
+			return err
+		}
+		fields = []string{string(obj)}
+	}
+	return d.w.w.Write(append(append([]string(nil), fields...), f.Code, msg))
+}
+
+// Flush writes any buffered data, returning any error that occurred.
+func (d *CSVDeadLetter) Flush() error {
+	return d.w.Flush()
+}
+
+// NewCSVDeadLetter returns a CSVDeadLetter writing to `w`, prefixed by the `header` row.
+func NewCSVDeadLetter(w io.Writer, header []string) *CSVDeadLetter {
+	cw := NewCSVWriter(w, header)
+	cw.columns = []string{"Code", "Error"}
+	return &CSVDeadLetter{cw}
+}
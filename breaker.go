@@ -0,0 +1,182 @@
+package melissa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of the client's circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed allows all requests.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails all requests fast with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen allows a single trial request to determine whether to close the circuit.
+	CircuitHalfOpen
+)
+
+var circuitStateNames = [...]string{
+	CircuitClosed:   "Closed",
+	CircuitOpen:     "Open",
+	CircuitHalfOpen: "HalfOpen",
+}
+
+func (s CircuitState) String() string {
+	if s < 0 || int(s) >= len(circuitStateNames) {
+		return "CircuitState(" + strconv.Itoa(int(s)) + ")"
+	}
+	return circuitStateNames[s]
+}
+
+// CircuitBreaker configures the client's circuit breaker.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures which opens the circuit.
+	Threshold int
+	// OpenTimeout is how long the circuit stays open before allowing a trial request.
+	OpenTimeout time.Duration
+	// OnStateChange, when set, is called whenever the circuit changes state.
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker fails requests fast with ErrCircuitOpen once `cb.Threshold`
+// consecutive requests have failed with network errors, timeouts or 5xx responses.
+// The breaker is shared with any service client built from the Client.
+func WithCircuitBreaker(cb CircuitBreaker) Option {
+	return func(c *Client) {
+		c.breaker = &breaker{config: cb}
+	}
+}
+
+// CircuitState returns the current state of the client's circuit breaker,
+// which is always CircuitClosed when the client doesn't have one.
+func (c Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	return c.breaker.state
+}
+
+// CircuitOpenError is returned, matching ErrCircuitOpen, while the circuit breaker is open.
+type CircuitOpenError struct {
+	// RetryAfter is how long until the breaker allows a trial request, or zero when unknown.
+	RetryAfter time.Duration
+}
+
+func (e CircuitOpenError) Error() string {
+	return ErrCircuitOpen.Error()
+}
+
+// Is reports whether `target` is ErrCircuitOpen.
+func (e CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// breaker is the state of a circuit breaker.
+type breaker struct {
+	config CircuitBreaker
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// allow returns whether a request may be made.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	from := b.state
+	allowed := true
+	switch b.state {
+	case CircuitOpen:
+		if allowed = time.Since(b.openedAt) >= b.config.OpenTimeout; allowed {
+			b.state = CircuitHalfOpen
+			b.trial = true
+		}
+	case CircuitHalfOpen:
+		// Only a single trial request is allowed at a time.
+		allowed = !b.trial
+		b.trial = true
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+	return allowed
+}
+
+// retryAfter returns how long until the open circuit allows a trial request, or zero
+// while a trial request is being made.
+func (b *breaker) retryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != CircuitOpen {
+		return 0
+	}
+	if d := b.config.OpenTimeout - time.Since(b.openedAt); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// observe records the outcome, `err`, of an allowed request. Network errors, timeouts
+// and 5xx responses are failures, while requests cancelled by the caller, rejected
+// with a 4xx response, or never sent, say nothing about the endpoint's health.
+func (b *breaker) observe(err error) {
+	var se StatusError
+	switch {
+	case err == nil:
+		b.record(false)
+	case errors.Is(err, context.Canceled), errors.Is(err, ErrBudgetExceeded), errors.Is(err, ErrCacheMiss):
+		b.release()
+	case errors.As(err, &se) && se.StatusCode < http.StatusInternalServerError:
+		b.release()
+	default:
+		b.record(true)
+	}
+}
+
+// release ends an allowed request without recording its outcome.
+func (b *breaker) release() {
+	b.mu.Lock()
+	b.trial = false
+	b.mu.Unlock()
+}
+
+// record records the outcome of an allowed request.
+func (b *breaker) record(failed bool) {
+	b.mu.Lock()
+	from := b.state
+	b.trial = false
+	switch {
+	case !failed:
+		b.failures = 0
+		b.state = CircuitClosed
+	case b.state == CircuitHalfOpen:
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	default:
+		if b.failures++; b.failures >= b.config.Threshold {
+			b.state = CircuitOpen
+			b.openedAt = time.Now()
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+}
+
+func (b *breaker) changed(from, to CircuitState) {
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,168 @@
+// Package businesscoder is a simple wrapper around Melissa Data's Business Coder service.
+package businesscoder
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/juztin/melissa"
+)
+
+const businessCoderURL = "https://businesscoder.melissadata.net/WEB/BusinessCoder/doBusinessCoderUS"
+
+// MaxRecords is the maximum number of records allowed in a single batch request.
+const MaxRecords = 100
+
+// ErrNoRecords is returned when the service doesn't return a record for a business.
+var ErrNoRecords = errors.New("no records returned")
+
+var (
+	// Result code mappings
+	ResultCodes = map[string]string{
+		"YS01": "Business Found by Name and Address",
+		"YS02": "Business Found by Phone",
+		"YS03": "Business Found by Address",
+		"YS04": "Business Found by Stock Ticker",
+		"YS05": "Business Found by Web Address",
+		"YS06": "Business Found by Address Key",
+		"YS07": "Business Location Moved",
+		"YS08": "Business Name Changed",
+
+		"YE01": "No Match Found",
+		"YE02": "Invalid Input",
+		"YE03": "Multiple Matches Found",
+	}
+)
+
+// Client used to communicate with Melissa Data's Business Coder service.
+type Client struct {
+	client melissa.Client
+	urlStr string
+}
+
+// Request is a single business to be verified and enriched.
+type Request struct {
+	RecordID     string
+	CompanyName  string
+	Phone        string
+	AddressLine1 string
+	AddressLine2 string
+	Suite        string
+	City         string
+	State        string
+	PostalCode   string
+	Country      string
+	StockTicker  string
+	WebAddress   string
+}
+
+// values returns the query params for the request, excluding empty values.
+func (r Request) values() url.Values {
+	qs := url.Values{}
+	for k, v := range map[string]string{
+		"rec":    r.RecordID,
+		"comp":   r.CompanyName,
+		"phone":  r.Phone,
+		"a1":     r.AddressLine1,
+		"a2":     r.AddressLine2,
+		"suite":  r.Suite,
+		"city":   r.City,
+		"state":  r.State,
+		"postal": r.PostalCode,
+		"ctry":   r.Country,
+		"stock":  r.StockTicker,
+		"web":    r.WebAddress,
+	} {
+		if v != "" {
+			qs.Set(k, v)
+		}
+	}
+	return qs
+}
+
+// Melissa Data Business Coder response type mapping
+type Response struct {
+	Records               []Record
+	TotalRecords          string
+	TransmissionReference string
+	TransmissionResults   string
+	Version               string
+}
+
+// Melissa Data Business Coder record type mapping
+type Record struct {
+	AddressLine1         string
+	City                 string
+	CompanyName          string
+	CurrentCompanyName   string
+	EmployeesEstimate    string
+	Latitude             string
+	LocationType         string
+	Longitude            string
+	MelissaAddressKey    string
+	MelissaEnterpriseKey string
+	NAICSCode1           string
+	NAICSCode2           string
+	NAICSCode3           string
+	NAICSDescription1    string
+	NAICSDescription2    string
+	NAICSDescription3    string
+	Phone                string
+	PostalCode           string
+	RecordID             string
+	Results              string
+	SalesEstimate        string
+	SICCode1             string
+	SICCode2             string
+	SICCode3             string
+	SICDescription1      string
+	SICDescription2      string
+	SICDescription3      string
+	State                string
+	StockTicker          string
+	Suite                string
+	WebAddress           string
+}
+
+// batchRequest is the JSON payload used for batch POST requests.
+type batchRequest struct {
+	melissa.Transmission
+	Records []Request
+}
+
+// Lookup verifies and enriches the single business `r`, returning the matched record.
+func (c Client) Lookup(ctx context.Context, r Request) (Record, error) {
+	resp, err := c.Query(ctx, r)
+	if err != nil {
+		return Record{}, err
+	}
+	if len(resp.Records) == 0 {
+		return Record{}, ErrNoRecords
+	}
+	return resp.Records[0], nil
+}
+
+// Query invokes a JSON GET request to verify the single business `r`.
+func (c Client) Query(ctx context.Context, r Request) (Response, error) {
+	var resp Response
+	err := c.client.Get(ctx, c.urlStr, r.values(), &resp)
+	return resp, err
+}
+
+// QueryBatch invokes a JSON POST request to verify all of the given `records`.
+// At most MaxRecords may be sent per request.
+func (c Client) QueryBatch(ctx context.Context, records []Request) (Response, error) {
+	var resp Response
+	body := &batchRequest{Records: records}
+	err := c.client.Post(ctx, c.urlStr, body, &resp)
+	return resp, err
+}
+
+// NewClient returns a new Business Coder client which uses `c` for communication.
+func NewClient(c melissa.Client) Client {
+	return Client{
+		client: c,
+		urlStr: c.URL(melissa.ServiceBusinessCoder, businessCoderURL),
+	}
+}
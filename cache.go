@@ -0,0 +1,195 @@
+package melissa
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// ErrCacheMiss is returned by a Cache without a response for a request, and by the
+// client when replaying from a cache without a recorded response.
+var ErrCacheMiss = errors.New("no cached response")
+
+// Cache stores recorded responses, keyed by normalized request.
+type Cache interface {
+	// Get returns the response stored for `key`, or ErrCacheMiss.
+	Get(key string) ([]byte, error)
+	// Put stores the response `data` for `key`.
+	Put(key string, data []byte) error
+}
+
+// CacheMode controls how the client uses its Cache.
+type CacheMode int
+
+const (
+	// CacheRecord replays cached responses, recording responses of requests which weren't cached.
+	CacheRecord CacheMode = iota
+	// CacheReplay only replays cached responses, failing requests which weren't cached
+	// with ErrCacheMiss, so no credits are consumed.
+	CacheReplay
+)
+
+// WithCache replays responses from `cache` for requests matching previously recorded ones,
+// allowing development and CI to run offline against recorded fixtures.
+// Requests are matched ignoring the key and TransmissionReference.
+func WithCache(cache Cache, mode CacheMode) Option {
+	return func(c *Client) {
+		c.cache = &cacheTransport{cache: cache, mode: mode}
+	}
+}
+
+// DiskCache is a Cache persisting each response as a file within a directory.
+type DiskCache string
+
+// NewDiskCache returns a DiskCache storing responses within `dir`, created when missing.
+func NewDiskCache(dir string) DiskCache {
+	return DiskCache(dir)
+}
+
+// Get implements Cache.
+func (d DiskCache) Get(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(d.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrCacheMiss
+	}
+	return data, err
+}
+
+// Put implements Cache, replacing the file atomically.
+func (d DiskCache) Put(key string, data []byte) error {
+	if err := os.MkdirAll(string(d), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(string(d), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(data); err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), d.path(key))
+}
+
+func (d DiskCache) path(key string) string {
+	return filepath.Join(string(d), key+".http")
+}
+
+// cacheTransport is an http.RoundTripper replaying and recording responses in a Cache.
+type cacheTransport struct {
+	cache Cache
+	mode  CacheMode
+	next  http.RoundTripper
+	// secrets are the query params holding the client's credential, ignored by cache keys.
+	secrets []string
+}
+
+// wrap returns a copy of the transport sending uncached requests using `next`, ignoring
+// the credential query `params` when matching requests.
+func (t *cacheTransport) wrap(next http.RoundTripper, params []string) *cacheTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &cacheTransport{t.cache, t.mode, next, params}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, err := cacheKey(req, t.secrets)
+	if err != nil {
+		return nil, err
+	}
+	data, err := t.cache.Get(key)
+	if err == nil {
+		return http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	} else if err != ErrCacheMiss || t.mode == CacheReplay {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	if data, err = httputil.DumpResponse(resp, true); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if err = t.cache.Put(key, data); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// volatileParams are the query params, other than the credential, which differ between
+// otherwise identical requests.
+var volatileParams = []string{"t"}
+
+// volatileXML matches the XML elements which differ between otherwise identical requests.
+var volatileXML = regexp.MustCompile(`<(CustomerID|TransmissionReference)>[^<]*</(CustomerID|TransmissionReference)>`)
+
+// cacheKey returns the key of `req`, normalized by ignoring the credential query `params`,
+// the key, TransmissionReference, parameter order and body compression.
+func cacheKey(req *http.Request, params []string) (string, error) {
+	u := *req.URL
+	qs := u.Query()
+	for _, p := range volatileParams {
+		qs.Del(p)
+	}
+	for _, p := range params {
+		qs.Del(p)
+	}
+	u.RawQuery = qs.Encode()
+
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + u.String() + "\n"))
+	if req.GetBody != nil {
+		body, err := requestBody(req)
+		if err != nil {
+			return "", err
+		}
+		var m map[string]interface{}
+		if json.Unmarshal(body, &m) == nil {
+			delete(m, "CustomerID")
+			delete(m, "TransmissionReference")
+			body, _ = json.Marshal(m)
+		} else {
+			body = volatileXML.ReplaceAll(body, nil)
+		}
+		h.Write(body)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// requestBody returns a decompressed copy of the body of `req`, leaving the body unread.
+func requestBody(req *http.Request) ([]byte, error) {
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(rc)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		return ioutil.ReadAll(gz)
+	}
+	return ioutil.ReadAll(rc)
+}
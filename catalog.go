@@ -0,0 +1,60 @@
+package melissa
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// CodeInfo describes a code within the catalog.
+type CodeInfo struct {
+	Category Category
+	Code     string
+	// Country is the country of address type codes, which differ by country.
+	Country     string `json:",omitempty"`
+	Description string
+	Severity    Severity
+}
+
+// Codes returns every known code (transmission, result, geocode and address type codes),
+// sorted by category then code, so they can be presented and configured without
+// hard-coding the code maps.
+func Codes() []CodeInfo {
+	var codes []CodeInfo
+	for code, desc := range TransmissionCodes {
+		codes = append(codes, CodeInfo{Category: CategoryTransmission, Code: code, Description: desc, Severity: SeverityBlocking})
+	}
+	for code := range ResultCodes {
+		codes = append(codes, Classify(code).info())
+	}
+	for code := range GeoCodes {
+		codes = append(codes, Classify(code).info())
+	}
+	for country, m := range map[string]map[string]string{"US": AddressCodesUS, "CA": AddressCodesCA} {
+		for code, desc := range m {
+			codes = append(codes, CodeInfo{Category: CategoryAddressType, Code: code, Country: country, Description: desc})
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		a, b := codes[i], codes[j]
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		if a.Country != b.Country {
+			return a.Country < b.Country
+		}
+		return a.Code < b.Code
+	})
+	return codes
+}
+
+// ExportCodes writes the catalog returned by Codes to `w` as a JSON array.
+func ExportCodes(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Codes())
+}
+
+func (c Classification) info() CodeInfo {
+	return CodeInfo{Category: c.Category, Code: c.Code, Description: c.Description, Severity: c.Severity}
+}
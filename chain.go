@@ -0,0 +1,75 @@
+package melissa
+
+import (
+	"context"
+	"errors"
+)
+
+// Chain is a Verifier which tries each of its verifiers in order, falling back to
+// the next when one is unavailable.
+type Chain struct {
+	Verifiers []Verifier
+	// Fallback reports whether an error warrants trying the next verifier,
+	// defaulting to Unavailable when nil.
+	Fallback func(err error) bool
+}
+
+var _ Verifier = Chain{}
+
+// Verify implements Verifier.
+func (ch Chain) Verify(ctx context.Context, r AddressRequest) (Result, error) {
+	var res Result
+	err := ch.try(func(v Verifier) (err error) {
+		res, err = v.Verify(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+// VerifyBatch implements Verifier.
+func (ch Chain) VerifyBatch(ctx context.Context, rs []AddressRequest) ([]Result, error) {
+	var res []Result
+	err := ch.try(func(v Verifier) (err error) {
+		res, err = v.VerifyBatch(ctx, rs)
+		return err
+	})
+	return res, err
+}
+
+// try calls `fn` with each verifier until one succeeds or fails without warranting a fallback.
+func (ch Chain) try(fn func(Verifier) error) error {
+	fallback := ch.Fallback
+	if fallback == nil {
+		fallback = Unavailable
+	}
+	err := errors.New("no verifiers")
+	for _, v := range ch.Verifiers {
+		if err = fn(v); err == nil || !fallback(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// Unavailable reports whether `err` indicates the service is unavailable, such as
+// an open circuit, exhausted quota, a network error, a 5xx response, or a server (SE)
+// transmission error.
+func Unavailable(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrQuotaExceeded) || retryable(err) {
+		return true
+	}
+	var te TransmissionError
+	if errors.As(err, &te) {
+		for _, code := range te.Codes {
+			if code == "SE01" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewChain returns a Chain trying each of the given verifiers in order.
+func NewChain(vs ...Verifier) Chain {
+	return Chain{Verifiers: vs}
+}
@@ -0,0 +1,114 @@
+package melissa
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WithBatchConcurrency sends up to `n` chunks of a batch larger than MaxRecords at once,
+// defaulting to sending them one at a time.
+func WithBatchConcurrency(n int) Option {
+	return func(c *Client) {
+		c.batchWorkers = n
+	}
+}
+
+// ChunkError is the failure of a chunk of a batch split by QueryBatch.
+type ChunkError struct {
+	// Offset is the index, within the batch, of the chunk's first record.
+	Offset int
+	// RecordIDs are the IDs of the chunk's records.
+	RecordIDs []string
+	Err       error
+}
+
+func (e ChunkError) Error() string {
+	return fmt.Sprintf("records %d-%d: %v", e.Offset+1, e.Offset+len(e.RecordIDs), e.Err)
+}
+
+func (e ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// ChunkErrors is returned by QueryBatch, along with the records of the successful chunks,
+// when chunks of a batch larger than MaxRecords fail.
+type ChunkErrors []ChunkError
+
+func (e ChunkErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ce := range e {
+		msgs[i] = ce.Error()
+	}
+	return fmt.Sprintf("%d of the batch chunks failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the error of each chunk.
+func (e ChunkErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, ce := range e {
+		errs[i] = ce
+	}
+	return errs
+}
+
+// queryChunks sends `records`, more than MaxRecords, as consecutive chunks of at most
+// MaxRecords, merging the responses in record order. Every chunk shares a TransmissionReference.
+// The call's timeout applies to the whole batch, rather than to each chunk.
+func (c Client) queryChunks(ctx context.Context, records []AddressRequest) (Response, error) {
+	if d := c.callTimeout(ctx); d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+		ctx = ContextWithCallOptions(ctx, WithTimeout(0))
+	}
+	ctx, ref := ensureReference(ctx)
+	records = prepareRecords(records)
+	n := (len(records) + MaxRecords - 1) / MaxRecords
+	resps := make([]Response, n)
+	errs := make([]error, n)
+
+	workers := c.batchWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			chunk := records[i*MaxRecords : min((i+1)*MaxRecords, len(records))]
+			if resps[i], errs[i] = c.queryBatch(ctx, chunk); errs[i] == nil {
+				errs[i] = resps[i].Err()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	merged := Response{TransmissionReference: ref}
+	var cerrs ChunkErrors
+	for i, resp := range resps {
+		if errs[i] != nil {
+			chunk := records[i*MaxRecords : min((i+1)*MaxRecords, len(records))]
+			ids := make([]string, len(chunk))
+			for j, r := range chunk {
+				ids[j] = r.RecordID
+			}
+			cerrs = append(cerrs, ChunkError{i * MaxRecords, ids, errs[i]})
+			continue
+		}
+		if merged.Version == "" {
+			merged.Version = resp.Version
+		}
+		merged.Records = append(merged.Records, resp.Records...)
+	}
+	merged.TotalRecords = strconv.Itoa(len(merged.Records))
+	if len(cerrs) > 0 {
+		return merged, cerrs
+	}
+	return merged, nil
+}
@@ -0,0 +1,113 @@
+package melissa
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Category is the kind of a code.
+type Category int
+
+const (
+	CategoryUnknown Category = iota
+	// CategoryStatus codes (AV) report the verification level of the address.
+	CategoryStatus
+	// CategoryChange codes (AC) report a component changed by Melissa Data.
+	CategoryChange
+	// CategoryError codes (AE) report why the address couldn't be verified.
+	CategoryError
+	// CategoryGeocodeStatus codes (GS) report the precision of the geocode.
+	CategoryGeocodeStatus
+	// CategoryGeocodeError codes (GE) report why the address couldn't be geocoded.
+	CategoryGeocodeError
+	// CategoryTransmission codes (GE, SE) report why a request failed.
+	CategoryTransmission
+	// CategoryAddressType codes describe the AddressType of a record.
+	CategoryAddressType
+)
+
+var categoryNames = [...]string{
+	CategoryUnknown:       "Unknown",
+	CategoryStatus:        "Status",
+	CategoryChange:        "Change",
+	CategoryError:         "Error",
+	CategoryGeocodeStatus: "GeocodeStatus",
+	CategoryGeocodeError:  "GeocodeError",
+	CategoryTransmission:  "Transmission",
+	CategoryAddressType:   "AddressType",
+}
+
+func (c Category) String() string {
+	if c < 0 || int(c) >= len(categoryNames) {
+		return "Category(" + strconv.Itoa(int(c)) + ")"
+	}
+	return categoryNames[c]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Category) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Severity is how a result code affects the usability of an address.
+type Severity int
+
+const (
+	// SeverityInfo codes are informational.
+	SeverityInfo Severity = iota
+	// SeverityWarning codes indicate the address may not be deliverable.
+	SeverityWarning
+	// SeverityBlocking codes indicate the address isn't deliverable.
+	SeverityBlocking
+)
+
+var severityNames = [...]string{
+	SeverityInfo:     "Info",
+	SeverityWarning:  "Warning",
+	SeverityBlocking: "Blocking",
+}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return "Severity(" + strconv.Itoa(int(s)) + ")"
+	}
+	return severityNames[s]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Classification describes a record level result code.
+type Classification struct {
+	Code        string
+	Category    Category
+	Severity    Severity
+	Description string
+}
+
+// Classify returns the classification of the record level result `code` (eg. "AE02").
+// Partial verification (AV1x) and geocode errors are warnings, address errors are
+// blocking, and everything else is informational. Unknown codes are warnings.
+// GE codes are classified as geocode errors, while SE codes are blocking transmission errors.
+func Classify(code string) Classification {
+	c := Classification{Code: code, Severity: SeverityWarning}
+	switch {
+	case strings.HasPrefix(code, "AV1"):
+		c.Category, c.Description = CategoryStatus, ResultCodes[code]
+	case strings.HasPrefix(code, "AV"):
+		c.Category, c.Severity, c.Description = CategoryStatus, SeverityInfo, ResultCodes[code]
+	case strings.HasPrefix(code, "AC"):
+		c.Category, c.Severity, c.Description = CategoryChange, SeverityInfo, ResultCodes[code]
+	case strings.HasPrefix(code, "AE"):
+		c.Category, c.Severity, c.Description = CategoryError, SeverityBlocking, ResultCodes[code]
+	case strings.HasPrefix(code, "GS"):
+		c.Category, c.Severity, c.Description = CategoryGeocodeStatus, SeverityInfo, GeoCodes[code]
+	case strings.HasPrefix(code, "GE"):
+		c.Category, c.Description = CategoryGeocodeError, GeoCodes[code]
+	case strings.HasPrefix(code, "SE"):
+		c.Category, c.Severity, c.Description = CategoryTransmission, SeverityBlocking, TransmissionCodes[code]
+	}
+	return c
+}
@@ -0,0 +1,173 @@
+// Command melissa verifies addresses using Melissa Data's GlobalAddress service.
+//
+// A single address may be given using flags, or as a JSON object on stdin:
+//
+//	melissa -a1 "22382 Avenida Empresa" -loc "Rancho Santa Margarita" -admarea CA -ctry US
+//	echo '{"AddressLine1":"22382 Avenida Empresa","PostalCode":"92688","Country":"US"}' | melissa
+//
+// A whole CSV or JSONL file may be verified by giving its path:
+//
+//	melissa -o verified.csv addresses.csv
+//
+// The catalog of Melissa Data's codes may be exported as JSON using -codes.
+//
+// The license key is read from the MELISSA_LICENSE_KEY environment variable.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/juztin/melissa"
+	"github.com/juztin/melissa/batch"
+)
+
+const keyEnv = "MELISSA_LICENSE_KEY"
+
+var (
+	jsonOut     = flag.Bool("json", false, "print results as JSON")
+	format      = flag.String("format", "", "bulk file format, csv or jsonl (default from the file extension)")
+	output      = flag.String("o", "", "bulk output file (default stdout)")
+	concurrency = flag.Int("c", 4, "number of concurrent bulk requests")
+	codes       = flag.Bool("codes", false, "print the code catalog as JSON and exit")
+
+	addr melissa.AddressRequest
+)
+
+func init() {
+	flag.StringVar(&addr.Organization, "org", "", "organization")
+	flag.StringVar(&addr.AddressLine1, "a1", "", "address line 1")
+	flag.StringVar(&addr.AddressLine2, "a2", "", "address line 2")
+	flag.StringVar(&addr.AddressLine3, "a3", "", "address line 3")
+	flag.StringVar(&addr.Locality, "loc", "", "locality (city)")
+	flag.StringVar(&addr.AdministrativeArea, "admarea", "", "administrative area (state/province)")
+	flag.StringVar(&addr.PostalCode, "postal", "", "postal code")
+	flag.StringVar(&addr.Country, "ctry", "", "country")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [file]\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	flag.Parse()
+	if *codes {
+		if err := melissa.ExportCodes(os.Stdout); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	key := os.Getenv(keyEnv)
+	if key == "" {
+		fatal(fmt.Errorf("%s must be set", keyEnv))
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c := melissa.NewClient(key)
+	var err error
+	switch {
+	case flag.NArg() > 0:
+		err = verifyFile(ctx, c, flag.Arg(0))
+	case addr != (melissa.AddressRequest{}):
+		err = verifyOne(ctx, c, addr)
+	default:
+		var r melissa.AddressRequest
+		if err = json.NewDecoder(os.Stdin).Decode(&r); err == nil {
+			err = verifyOne(ctx, c, r)
+		}
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// verifyOne verifies and prints the single address `r`.
+func verifyOne(ctx context.Context, c melissa.Client, r melissa.AddressRequest) error {
+	res, err := c.Verify(ctx, r)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	fmt.Fprintf(w, "Outcome:  %s\n", res.Outcome)
+	for _, code := range res.Codes() {
+		fmt.Fprintf(w, "Result:   %s %s\n", code, describe(code))
+	}
+	fmt.Fprintln(w, "Address:")
+	for _, line := range strings.Split(res.FormattedAddress, ";") {
+		if line != "" {
+			fmt.Fprintf(w, "          %s\n", line)
+		}
+	}
+	if res.Latitude != "" {
+		fmt.Fprintf(w, "Geocode:  %s, %s\n", res.Latitude, res.Longitude)
+	}
+	return w.Flush()
+}
+
+// describe returns the description of the given result `code`.
+func describe(code string) string {
+	if d, ok := melissa.ResultCodes[code]; ok {
+		return d
+	}
+	return melissa.GeoCodes[code]
+}
+
+// verifyFile verifies every address within the CSV or JSONL file at `path`.
+func verifyFile(ctx context.Context, c melissa.Client, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		out, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		w = out
+	}
+
+	fmtName := *format
+	if fmtName == "" {
+		fmtName = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	b := batch.New(c, batch.WithConcurrency(*concurrency))
+	var sum batch.Summary
+	switch fmtName {
+	case "csv":
+		sum, err = b.ProcessCSV(ctx, f, w, batch.DefaultMapping)
+	case "jsonl", "ndjson":
+		sum, err = b.ProcessJSONL(ctx, f, w, batch.DefaultMapping)
+	default:
+		return fmt.Errorf("unknown format %q", fmtName)
+	}
+	fmt.Fprintf(os.Stderr, "verified %d addresses", sum.Total)
+	for _, outcome := range []melissa.Outcome{melissa.Verified, melissa.Corrected, melissa.PartiallyVerified, melissa.Failed} {
+		fmt.Fprintf(os.Stderr, ", %s: %d", outcome, sum.Outcomes[outcome])
+	}
+	fmt.Fprintln(os.Stderr)
+	return err
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
+	os.Exit(1)
+}
@@ -0,0 +1,76 @@
+package melissa
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Comparison is the similarity of two records.
+type Comparison struct {
+	// Score is the weighted fraction, 0 to 1, of the compared fields which match.
+	Score float64
+	// Matches reports whether each compared field matches.
+	Matches map[string]bool
+	// SameAddress is set when both records have the same AddressKey.
+	SameAddress bool
+}
+
+// compareWeights are the weights of each compared field.
+var compareWeights = []struct {
+	field  string
+	weight float64
+	value  func(Record) string
+}{
+	{"PremisesNumber", 0.25, func(r Record) string { return r.PremisesNumber }},
+	{"Thoroughfare", 0.25, func(r Record) string {
+		if r.ThoroughfareName == "" {
+			return r.AddressLine1
+		}
+		return r.ThoroughfareName
+	}},
+	{"SubPremisesNumber", 0.15, func(r Record) string { return r.SubPremisesNumber }},
+	{"PostalCode", 0.15, func(r Record) string {
+		// Compare US ZIP codes without their +4.
+		if i := strings.IndexByte(r.PostalCode, '-'); i > 0 && r.CountryISO3166_1_Alpha2 == "US" {
+			return r.PostalCode[:i]
+		}
+		return r.PostalCode
+	}},
+	{"Locality", 0.1, func(r Record) string { return r.Locality }},
+	{"AdministrativeArea", 0.05, func(r Record) string { return r.AdministrativeArea }},
+	{"Country", 0.05, func(r Record) string { return r.CountryISO3166_1_Alpha2 }},
+}
+
+// Compare returns the similarity of the verified records `a` and `b`. Fields are compared
+// ignoring case, punctuation and whitespace, and fields empty in both records are ignored.
+func Compare(a, b Record) Comparison {
+	c := Comparison{
+		Matches:     map[string]bool{},
+		SameAddress: a.AddressKey != "" && a.AddressKey == b.AddressKey,
+	}
+	var total, matched float64
+	for _, w := range compareWeights {
+		va, vb := normalizeField(w.value(a)), normalizeField(w.value(b))
+		if va == "" && vb == "" {
+			continue
+		}
+		total += w.weight
+		if c.Matches[w.field] = va == vb; va == vb {
+			matched += w.weight
+		}
+	}
+	if total > 0 {
+		c.Score = matched / total
+	}
+	return c
+}
+
+// normalizeField returns `s` upper cased with everything but letters and digits removed.
+func normalizeField(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,136 @@
+package melissa
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// CostReport is a running total of the lookups made by a client or call.
+type CostReport struct {
+	// Requests are the request attempts sent, including retries and chunks.
+	Requests int64
+	// Records are the records sent by all attempts, each potentially billable.
+	Records int64
+	// Billed are the records of attempts which Melissa Data successfully responded to.
+	Billed int64
+}
+
+// CostTracker counts the lookups made, optionally enforcing a budget.
+// It's safe for concurrent use.
+type CostTracker struct {
+	mu     sync.Mutex
+	report CostReport
+	budget int64
+}
+
+// NewCostTracker returns a CostTracker failing requests, with ErrBudgetExceeded, which
+// would send more than `budget` records in total. A zero budget is unlimited.
+func NewCostTracker(budget int64) *CostTracker {
+	return &CostTracker{budget: budget}
+}
+
+// Report returns the running totals.
+func (t *CostTracker) Report() CostReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.report
+}
+
+// reserve counts an attempt sending `n` records, unless it would exceed the budget.
+func (t *CostTracker) reserve(n int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.budget > 0 && t.report.Records+n > t.budget {
+		return ErrBudgetExceeded
+	}
+	t.report.Requests++
+	t.report.Records += n
+	return nil
+}
+
+// release uncounts an attempt, sending `n` records, which was reserved but not sent.
+func (t *CostTracker) release(n int64) {
+	t.mu.Lock()
+	t.report.Requests--
+	t.report.Records -= n
+	t.mu.Unlock()
+}
+
+// bill counts `n` records as billed.
+func (t *CostTracker) bill(n int64) {
+	t.mu.Lock()
+	t.report.Billed += n
+	t.mu.Unlock()
+}
+
+// WithCostTracker counts every lookup made by the client, including those of service
+// clients built from it, within `t`.
+func WithCostTracker(t *CostTracker) Option {
+	return func(c *Client) {
+		c.cost = t
+	}
+}
+
+// WithCost additionally counts the lookups of the call within `t`, eg. to track, or
+// limit, the cost of a single bulk job.
+func WithCost(t *CostTracker) CallOption {
+	return func(cfg *callConfig) {
+		cfg.costs = append(cfg.costs, t)
+	}
+}
+
+type recordsKey struct{}
+
+// withRecords returns a copy of `ctx` noting that requests made with it send `n` records.
+func withRecords(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, recordsKey{}, n)
+}
+
+// recordCount returns the number of records within the POST payload `body`: the length
+// of its Records field, or 1 when it hasn't one.
+func recordCount(body interface{}) int {
+	v := reflect.Indirect(reflect.ValueOf(body))
+	if v.Kind() != reflect.Struct {
+		return 1
+	}
+	if f := v.FieldByName("Records"); f.IsValid() && f.Kind() == reflect.Slice {
+		return f.Len()
+	}
+	return 1
+}
+
+// costTrackers returns the trackers counting requests made with `ctx`.
+func (c Client) costTrackers(ctx context.Context) []*CostTracker {
+	var ts []*CostTracker
+	if c.cost != nil {
+		ts = append(ts, c.cost)
+	}
+	if cfg, ok := ctx.Value(callKey{}).(callConfig); ok {
+		ts = append(ts, cfg.costs...)
+	}
+	return ts
+}
+
+// reserveCost counts the attempt `req` within every tracker, failing with ErrBudgetExceeded
+// without counting it anywhere when any budget would be exceeded.
+func (c Client) reserveCost(req *http.Request) ([]*CostTracker, int64, error) {
+	ts := c.costTrackers(req.Context())
+	if len(ts) == 0 {
+		return nil, 0, nil
+	}
+	n, ok := req.Context().Value(recordsKey{}).(int)
+	if !ok {
+		n = 1
+	}
+	for i, t := range ts {
+		if err := t.reserve(int64(n)); err != nil {
+			for _, r := range ts[:i] {
+				r.release(int64(n))
+			}
+			return nil, 0, err
+		}
+	}
+	return ts, int64(n), nil
+}
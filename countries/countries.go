@@ -0,0 +1,58 @@
+// Package countries normalizes country names, aliases, and codes to ISO 3166-1 codes.
+package countries
+
+import "strings"
+
+// Country is an ISO 3166-1 country.
+type Country struct {
+	Name    string
+	Alpha2  string
+	Alpha3  string
+	Numeric string
+}
+
+// index maps normalized names, aliases, and codes to countries.
+var index = func() map[string]Country {
+	m := make(map[string]Country, len(countries)*4+len(aliases))
+	byAlpha2 := map[string]Country{}
+	for _, c := range countries {
+		byAlpha2[c.Alpha2] = c
+		for _, k := range []string{c.Name, c.Alpha2, c.Alpha3, c.Numeric} {
+			m[key(k)] = c
+		}
+	}
+	for alias, alpha2 := range aliases {
+		m[key(alias)] = byAlpha2[alpha2]
+	}
+	return m
+}()
+
+// key normalizes `s` for lookup, ignoring case, punctuation, and extra whitespace.
+func key(s string) string {
+	s = strings.ToLower(s)
+	s = strings.NewReplacer(".", "", "'", "", "’", "", ",", " ", "&", " and ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Lookup returns the country for the given name, alias, or alpha-2, alpha-3,
+// or numeric code, ignoring case and punctuation (eg. "United Kingdom", "uk", "GBR").
+func Lookup(s string) (Country, bool) {
+	c, ok := index[key(s)]
+	return c, ok
+}
+
+// Alpha2 returns the ISO 3166-1 alpha-2 code for `s`, or `s` unchanged when it isn't recognized.
+func Alpha2(s string) string {
+	if c, ok := Lookup(s); ok {
+		return c.Alpha2
+	}
+	return s
+}
+
+// Alpha3 returns the ISO 3166-1 alpha-3 code for `s`, or `s` unchanged when it isn't recognized.
+func Alpha3(s string) string {
+	if c, ok := Lookup(s); ok {
+		return c.Alpha3
+	}
+	return s
+}
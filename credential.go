@@ -0,0 +1,64 @@
+package melissa
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Credential authenticates requests with Melissa Data.
+type Credential interface {
+	// Key returns the credential, sent as the CustomerID of POST payloads.
+	Key() string
+	// Authenticate adds the credential to the query params `qs` of GET requests, which
+	// is nil for POST requests, or to the headers `h` of any request.
+	Authenticate(qs url.Values, h http.Header)
+}
+
+// CustomerID is a Melissa Data customer ID, sent as the "id" query param.
+type CustomerID string
+
+// Key implements Credential.
+func (id CustomerID) Key() string {
+	return string(id)
+}
+
+// Authenticate implements Credential.
+func (id CustomerID) Authenticate(qs url.Values, h http.Header) {
+	if qs != nil {
+		qs.Set("id", string(id))
+	}
+}
+
+// LicenseKey is a long-form Melissa Data license string.
+type LicenseKey struct {
+	License string
+	// Param is the query param the license is sent as, defaulting to "license".
+	Param string
+	// Header, when set, is the header the license is sent as instead of a query param.
+	Header string
+}
+
+// Key implements Credential.
+func (l LicenseKey) Key() string {
+	return l.License
+}
+
+// Authenticate implements Credential.
+func (l LicenseKey) Authenticate(qs url.Values, h http.Header) {
+	switch {
+	case l.Header != "":
+		h.Set(l.Header, l.License)
+	case qs != nil && l.Param != "":
+		qs.Set(l.Param, l.License)
+	case qs != nil:
+		qs.Set("license", l.License)
+	}
+}
+
+// WithCredential authenticates using `cred` instead of the key given to NewClient.
+// The credential is shared with any service client built from the Client.
+func WithCredential(cred Credential) Option {
+	return func(c *Client) {
+		c.cred = cred
+	}
+}
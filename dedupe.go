@@ -0,0 +1,20 @@
+package melissa
+
+import "strings"
+
+// DedupeKey returns a key identifying the address of the record, for detecting duplicates.
+// The record's AddressKey is used when present, otherwise the key is built from its
+// normalized (upper cased, whitespace collapsed) address lines, postal code and country.
+func (r Record) DedupeKey() string {
+	if r.AddressKey != "" {
+		return r.AddressKey
+	}
+	parts := []string{r.CountryISO3166_1_Alpha2, r.PostalCode, r.AddressLine1, r.AddressLine2}
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(strings.Join(strings.Fields(p), " "))
+	}
+	if parts[2] == "" {
+		return ""
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,65 @@
+package melissa
+
+// Change is a single field changed by Melissa Data during verification.
+type Change struct {
+	Field string
+	From  string
+	To    string
+	// Code is the change (AC) code reported for the field, if any.
+	Code string
+}
+
+// changeCodes maps change (AC) codes to the field they apply to.
+var changeCodes = map[string]string{
+	"AC01": "PostalCode",
+	"AC02": "AdministrativeArea",
+	"AC03": "Locality",
+	"AC09": "DependentLocality",
+	"AC10": "AddressLine1",
+	"AC11": "AddressLine1",
+	"AC12": "AddressLine1",
+	"AC13": "AddressLine1",
+	"AC14": "AddressLine1",
+	"AC15": "DoubleDependentLocality",
+	"AC16": "SubAdministrativeArea",
+	"AC17": "SubNationalArea",
+}
+
+// Diff returns the field level changes between the `input` address and the returned `rec`.
+// Each change is annotated with the first change (AC) code reported for the field.
+//
+// AddressLine2 is only compared when given, against the returned SubPremises, as Melissa
+// Data returns the last line of the formatted address within AddressLine2.
+func Diff(input AddressRequest, rec Record) []Change {
+	codes := map[string]string{}
+	for _, code := range splitCodes(rec.Results) {
+		if f, ok := changeCodes[code]; ok && codes[f] == "" {
+			codes[f] = code
+		}
+	}
+
+	var changes []Change
+	for _, f := range []struct {
+		name     string
+		from, to string
+	}{
+		{"Organization", input.Organization, rec.Organization},
+		{"AddressLine1", input.AddressLine1, rec.AddressLine1},
+		{"AddressLine2", input.AddressLine2, rec.SubPremises},
+		{"DoubleDependentLocality", input.DoubleDependentLocality, rec.DoubleDependentLocality},
+		{"DependentLocality", input.DependentLocality, rec.DependentLocality},
+		{"Locality", input.Locality, rec.Locality},
+		{"SubAdministrativeArea", input.SubAdministrativeArea, rec.SubAdministrativeArea},
+		{"AdministrativeArea", input.AdministrativeArea, rec.AdministrativeArea},
+		{"PostalCode", input.PostalCode, rec.PostalCode},
+		{"SubNationalArea", input.SubNationalArea, rec.SubNationalArea},
+	} {
+		if f.name == "AddressLine2" && f.from == "" {
+			continue
+		}
+		if f.from != f.to || codes[f.name] != "" {
+			changes = append(changes, Change{f.name, f.from, f.to, codes[f.name]})
+		}
+	}
+	return changes
+}
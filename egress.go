@@ -0,0 +1,28 @@
+package melissa
+
+import (
+	"context"
+	"net/http"
+)
+
+// NewRequest returns the GET request the client would send to verify the address `r`,
+// so that it may be inspected or modified (eg. signed) before being sent using Do.
+func (c Client) NewRequest(r AddressRequest) (*http.Request, error) {
+	return c.NewRequestContext(context.Background(), r)
+}
+
+// NewRequestContext is like NewRequest, using `ctx` for the lifetime of the request.
+func (c Client) NewRequestContext(ctx context.Context, r AddressRequest) (*http.Request, error) {
+	r, err := c.prepare(r)
+	if err != nil {
+		return nil, err
+	}
+	return c.newQuery(ctx, r.Values())
+}
+
+// Do sends `req`, typically built using NewRequest, as the client sends any other request
+// (with retries, hooks and metrics), returning the unmarshalled response.
+// The request's Accept header selects the response format.
+func (c Client) Do(req *http.Request) (Response, error) {
+	return c.doResponse(req)
+}
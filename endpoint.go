@@ -0,0 +1,93 @@
+package melissa
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+)
+
+// Service identifies one of Melissa Data's web services.
+type Service string
+
+const (
+	ServiceGlobalAddress Service = "GlobalAddress"
+	ServiceGlobalName    Service = "GlobalName"
+	ServiceReverseGeo    Service = "ReverseGeoCoder"
+	ServiceProperty      Service = "Property"
+	ServiceBusinessCoder Service = "BusinessCoder"
+	ServiceGlobalIP      Service = "GlobalIP"
+	ServiceExpressEntry  Service = "ExpressEntry"
+	// ServicePropertyDeeds is the Property service's LookupDeeds endpoint, configured
+	// separately from ServiceProperty's LookupProperty endpoint.
+	ServicePropertyDeeds Service = "PropertyDeeds"
+)
+
+// EndpointProfile describes where Melissa Data's services are hosted, allowing the
+// client to switch between Melissa Data's cloud, regional, and on-premise deployments.
+type EndpointProfile struct {
+	Name string
+	// BaseURLs overrides the scheme and host (eg. "https://melissa.internal:8443") of each service.
+	// Services without a base URL use Melissa Data's cloud.
+	BaseURLs map[Service]string
+	// Paths overrides the path of each service, for deployments hosting them at non-standard paths.
+	Paths map[Service]string
+	// TLSConfig, when set, is used for all connections (eg. to trust a private CA).
+	TLSConfig *tls.Config
+}
+
+// CloudProfile uses Melissa Data's cloud for all services.
+var CloudProfile = EndpointProfile{Name: "cloud"}
+
+// OnPremiseProfile returns a profile hosting all services at `baseURL`, connecting using `tlsConfig`.
+func OnPremiseProfile(baseURL string, tlsConfig *tls.Config) EndpointProfile {
+	p := EndpointProfile{
+		Name:      "on-premise",
+		BaseURLs:  map[Service]string{},
+		TLSConfig: tlsConfig,
+	}
+	for _, s := range []Service{ServiceGlobalAddress, ServiceGlobalName, ServiceReverseGeo, ServiceProperty, ServiceBusinessCoder, ServiceGlobalIP, ServiceExpressEntry, ServicePropertyDeeds} {
+		p.BaseURLs[s] = baseURL
+	}
+	return p
+}
+
+// WithEndpointProfile sends requests for each service to the endpoints of `p`.
+func WithEndpointProfile(p EndpointProfile) Option {
+	return func(c *Client) {
+		c.profile = p
+		if p.TLSConfig != nil {
+			c.transport().TLSClientConfig = p.TLSConfig
+		}
+	}
+}
+
+// URL returns the URL of the service `s` within the client's endpoint profile, where
+// `defaultURL` is the service's URL within Melissa Data's cloud.
+func (c Client) URL(s Service, defaultURL string) string {
+	base, path := c.profile.BaseURLs[s], c.profile.Paths[s]
+	if base == "" && path == "" {
+		return defaultURL
+	}
+	u, err := url.Parse(defaultURL)
+	if err != nil {
+		return defaultURL
+	}
+	if b, err := url.Parse(base); err == nil && base != "" {
+		u.Scheme, u.Host = b.Scheme, b.Host
+		u.Path = b.Path + u.Path
+	}
+	if path != "" {
+		u.Path = path
+	}
+	return u.String()
+}
+
+// transport returns the client's own transport, created the first time it's needed.
+func (c *Client) transport() *http.Transport {
+	t, ok := c.client.Transport.(*http.Transport)
+	if !ok {
+		t = newTransport()
+		c.client.Transport = t
+	}
+	return t
+}
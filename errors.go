@@ -0,0 +1,144 @@
+package melissa
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNoRecords is returned when the service doesn't return a record for an address.
+	ErrNoRecords = errors.New("no records returned")
+	// ErrCircuitOpen matches the CircuitOpenError returned, without making a request, while
+	// the circuit breaker is open.
+	ErrCircuitOpen = errors.New("circuit breaker open")
+	// ErrBudgetExceeded is returned, without making a request, when it would exceed a CostTracker's budget.
+	ErrBudgetExceeded = errors.New("cost budget exceeded")
+
+	// ErrInvalidKey matches errors due to an empty or invalid key (GE04, GE05, GE08).
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrAccountDisabled matches errors due to a disabled account (GE06).
+	ErrAccountDisabled = errors.New("account disabled")
+	// ErrQuotaExceeded matches errors due to exhausted credits (GE14) or rate limits.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+// codeErrors maps transmission codes to the errors they match.
+var codeErrors = map[string]error{
+	"GE04": ErrInvalidKey,
+	"GE05": ErrInvalidKey,
+	"GE06": ErrAccountDisabled,
+	"GE08": ErrInvalidKey,
+	"GE14": ErrQuotaExceeded,
+}
+
+// TransmissionError is returned when Melissa Data reports transmission level errors.
+type TransmissionError struct {
+	Codes     []string
+	Reference string
+}
+
+func (e TransmissionError) Error() string {
+	msgs := make([]string, len(e.Codes))
+	for i, code := range e.Codes {
+		if msg, ok := TransmissionCodes[code]; ok {
+			msgs[i] = code + ": " + msg
+		} else {
+			msgs[i] = code
+		}
+	}
+	return "transmission error, " + strings.Join(msgs, ", ") + ", for transmission " + e.Reference
+}
+
+// Is reports whether any of the transmission codes match the `target` error,
+// allowing billing problems to be detected using errors.Is(err, ErrAccountDisabled).
+func (e TransmissionError) Is(target error) bool {
+	for _, code := range e.Codes {
+		if codeErrors[code] == target {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordFailure is a record of a batch which Melissa Data failed to process.
+type RecordFailure struct {
+	RecordID string
+	// Codes are the record's blocking transmission (SE) and geocode error (GE) codes.
+	Codes []string
+}
+
+// PartialError is returned by QueryBatch and VerifyBatch, along with the successful records,
+// when some of the records come back with blocking transmission (SE) or geocode error (GE)
+// codes while the others verify.
+type PartialError struct {
+	Failures  []RecordFailure
+	Reference string
+}
+
+func (e *PartialError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = f.RecordID + ": " + strings.Join(f.Codes, ",")
+	}
+	return fmt.Sprintf("%d records failed, %s, for transmission %s", len(e.Failures), strings.Join(msgs, "; "), e.Reference)
+}
+
+// RecordIDs returns the IDs of the failed records.
+func (e *PartialError) RecordIDs() []string {
+	ids := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		ids[i] = f.RecordID
+	}
+	return ids
+}
+
+// Is reports whether any of the failed records' codes match the `target` error.
+func (e *PartialError) Is(target error) bool {
+	for _, f := range e.Failures {
+		if (TransmissionError{Codes: f.Codes}).Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// partialError splits the records of `r` with blocking transmission or geocode error codes
+// from the others, returning `r` with only the successful records along with a PartialError
+// for the failed ones, or nil when there are none.
+func partialError(r Response) (Response, error) {
+	var fs []RecordFailure
+	var ok []Record
+	for _, rec := range r.Records {
+		var codes []string
+		for _, code := range splitCodes(rec.Results) {
+			c := Classify(code)
+			if c.Category == CategoryGeocodeError || c.Category == CategoryTransmission && c.Severity == SeverityBlocking {
+				codes = append(codes, code)
+			}
+		}
+		if len(codes) > 0 {
+			fs = append(fs, RecordFailure{rec.RecordID, codes})
+		} else {
+			ok = append(ok, rec)
+		}
+	}
+	if len(fs) == 0 {
+		return r, nil
+	}
+	r.Records = ok
+	r.TotalRecords = strconv.Itoa(len(ok))
+	return r, &PartialError{fs, r.TransmissionReference}
+}
+
+// splitCodes splits a comma separated list of result codes.
+func splitCodes(s string) []string {
+	var codes []string
+	for _, code := range strings.Split(s, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
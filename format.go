@@ -0,0 +1,71 @@
+package melissa
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+)
+
+// Format is the encoding used for GlobalAddress requests and responses.
+type Format int
+
+const (
+	// FormatJSON encodes requests and responses as JSON (the default).
+	FormatJSON Format = iota
+	// FormatXML encodes requests and responses as XML, for legacy and on-premise services.
+	FormatXML
+	// FormatSOAP encodes requests and responses as XML within SOAP envelopes, using
+	// Melissa Data's SOAP service, for integrations yet to migrate off it.
+	// Single address queries are sent as single record batches.
+	FormatSOAP
+)
+
+// WithFormat uses the given format for GlobalAddress requests and responses.
+// Service subpackages always use JSON. FormatSOAP also switches to Melissa Data's
+// SOAP service, unless a URL is given using WithURL.
+func WithFormat(f Format) Option {
+	return func(c *Client) {
+		c.format = f
+	}
+}
+
+// formatOf returns the format for the given content type.
+func formatOf(contentType string) Format {
+	switch contentType {
+	case "application/xml":
+		return FormatXML
+	case "text/xml; charset=utf-8":
+		return FormatSOAP
+	}
+	return FormatJSON
+}
+
+func (f Format) contentType() string {
+	switch f {
+	case FormatXML:
+		return "application/xml"
+	case FormatSOAP:
+		return "text/xml; charset=utf-8"
+	}
+	return "application/json"
+}
+
+func (f Format) marshal(v interface{}) ([]byte, error) {
+	switch f {
+	case FormatXML:
+		return xml.Marshal(v)
+	case FormatSOAP:
+		return marshalSOAP(v)
+	}
+	return json.Marshal(v)
+}
+
+func (f Format) decode(r io.Reader, v interface{}) error {
+	switch f {
+	case FormatXML:
+		return xml.NewDecoder(r).Decode(v)
+	case FormatSOAP:
+		return decodeSOAP(r, v)
+	}
+	return json.NewDecoder(r).Decode(v)
+}
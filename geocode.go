@@ -0,0 +1,56 @@
+package melissa
+
+import "strconv"
+
+// GeocodeLevel is the precision of a record's geocode, ordered from least to most precise.
+type GeocodeLevel int
+
+const (
+	// GeocodeNone records weren't geocoded.
+	GeocodeNone GeocodeLevel = iota
+	// GeocodeZipCentroid is the centroid of the 5-digit ZIP (or 3-digit Canadian postal) code (GS03).
+	GeocodeZipCentroid
+	// GeocodeZip2Centroid is the centroid of the ZIP+2 code (GS02).
+	GeocodeZip2Centroid
+	// GeocodeZip4Centroid is the centroid of the ZIP+4 (or 6-digit Canadian postal) code (GS01).
+	GeocodeZip4Centroid
+	// GeocodeInterpolatedRooftop is an interpolated rooftop point (GS06).
+	GeocodeInterpolatedRooftop
+	// GeocodeRooftop is a rooftop point (GS05).
+	GeocodeRooftop
+)
+
+var geocodeLevels = map[string]GeocodeLevel{
+	"GS01": GeocodeZip4Centroid,
+	"GS02": GeocodeZip2Centroid,
+	"GS03": GeocodeZipCentroid,
+	"GS05": GeocodeRooftop,
+	"GS06": GeocodeInterpolatedRooftop,
+}
+
+var geocodeLevelNames = [...]string{
+	GeocodeNone:                "None",
+	GeocodeZipCentroid:         "ZipCentroid",
+	GeocodeZip2Centroid:        "Zip2Centroid",
+	GeocodeZip4Centroid:        "Zip4Centroid",
+	GeocodeInterpolatedRooftop: "InterpolatedRooftop",
+	GeocodeRooftop:             "Rooftop",
+}
+
+func (l GeocodeLevel) String() string {
+	if l < 0 || int(l) >= len(geocodeLevelNames) {
+		return "GeocodeLevel(" + strconv.Itoa(int(l)) + ")"
+	}
+	return geocodeLevelNames[l]
+}
+
+// GeocodeLevel returns the precision of the record's geocode, from its GS code.
+func (r Record) GeocodeLevel() GeocodeLevel {
+	level := GeocodeNone
+	for _, code := range r.Codes() {
+		if l := geocodeLevels[code]; l > level {
+			level = l
+		}
+	}
+	return level
+}
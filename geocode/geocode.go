@@ -0,0 +1,78 @@
+// Package geocode is a simple wrapper around Melissa Data's Reverse GeoCoder service.
+package geocode
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/juztin/melissa"
+)
+
+const reverseGeoURL = "https://reversegeo.melissadata.net/v3/web/ReverseGeoCode/doLookup"
+
+// Client used to communicate with Melissa Data's Reverse GeoCoder service.
+type Client struct {
+	client melissa.Client
+	urlStr string
+}
+
+// Request is a reverse lookup around a single point.
+type Request struct {
+	Latitude  float64
+	Longitude float64
+	// MaxDistance is the search radius in miles, ignored when zero.
+	MaxDistance float64
+	// MaxRecords is the maximum number of records to return, ignored when zero.
+	MaxRecords int
+}
+
+// Melissa Data Reverse GeoCoder response type mapping
+type Response struct {
+	Records               []Record
+	TotalRecords          string
+	TransmissionReference string
+	TransmissionResults   string
+	Version               string
+}
+
+// Melissa Data Reverse GeoCoder record type mapping
+type Record struct {
+	AddressKey            string
+	AddressLine1          string
+	City                  string
+	Distance              string
+	Latitude              string
+	Longitude             string
+	MelissaAddressKey     string
+	MelissaAddressKeyBase string
+	PostalCode            string
+	Results               string
+	State                 string
+	SuiteCount            string
+	SuiteName             string
+}
+
+// Lookup returns the address records nearest to the point within `r`.
+func (c Client) Lookup(ctx context.Context, r Request) (Response, error) {
+	var resp Response
+	qs := url.Values{}
+	qs.Set("lat", strconv.FormatFloat(r.Latitude, 'f', -1, 64))
+	qs.Set("long", strconv.FormatFloat(r.Longitude, 'f', -1, 64))
+	if r.MaxDistance > 0 {
+		qs.Set("dist", strconv.FormatFloat(r.MaxDistance, 'f', -1, 64))
+	}
+	if r.MaxRecords > 0 {
+		qs.Set("recs", strconv.Itoa(r.MaxRecords))
+	}
+	err := c.client.Get(ctx, c.urlStr, qs, &resp)
+	return resp, err
+}
+
+// NewClient returns a new Reverse GeoCoder client which uses `c` for communication.
+func NewClient(c melissa.Client) Client {
+	return Client{
+		client: c,
+		urlStr: c.URL(melissa.ServiceReverseGeo, reverseGeoURL),
+	}
+}
@@ -0,0 +1,71 @@
+package melissa
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// QuotaHeader is the response header, when present, holding the remaining request quota.
+const QuotaHeader = "X-RateLimit-Remaining"
+
+// HealthReport is the health of the client and its connectivity to Melissa Data.
+type HealthReport struct {
+	HealthStatus
+	// LastSuccess is the time of the last successful request, zero when there wasn't one.
+	LastSuccess time.Time
+	Circuit     CircuitState
+	// RemainingQuota is the last reported remaining quota, or -1 when unknown.
+	RemainingQuota int
+}
+
+// HealthChecker reports the health of a dependency, for readiness endpoints.
+type HealthChecker interface {
+	Health(ctx context.Context) (HealthReport, error)
+}
+
+var _ HealthChecker = Client{}
+
+// Health pings Melissa Data and reports the client's health, returning an error when
+// the service is unreachable or the circuit breaker is open.
+func (c Client) Health(ctx context.Context) (HealthReport, error) {
+	h, err := c.Ping(ctx, "")
+	r := HealthReport{HealthStatus: h, Circuit: c.CircuitState(), RemainingQuota: -1}
+	if c.state != nil {
+		c.state.mu.Lock()
+		r.LastSuccess, r.RemainingQuota = c.state.lastSuccess, c.state.remaining
+		c.state.mu.Unlock()
+	}
+	if err == nil && r.Circuit == CircuitOpen {
+		err = ErrCircuitOpen
+	}
+	return r, err
+}
+
+// clientState is the state shared between copies of a Client.
+type clientState struct {
+	mu          sync.Mutex
+	lastSuccess time.Time
+	remaining   int
+}
+
+func newClientState() *clientState {
+	return &clientState{remaining: -1}
+}
+
+// record records the outcome of a request attempt.
+func (s *clientState) record(resp *http.Response, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		s.lastSuccess = time.Now()
+	}
+	if resp == nil {
+		return
+	}
+	if n, perr := strconv.Atoi(resp.Header.Get(QuotaHeader)); perr == nil {
+		s.remaining = n
+	}
+}
@@ -0,0 +1,42 @@
+package melissa
+
+import (
+	"net/http"
+	"time"
+)
+
+// RoundTrip describes a single request attempt made by the client.
+type RoundTrip struct {
+	Request *http.Request
+	// Response is nil when the request failed before a response was received.
+	Response *http.Response
+	// Body is the raw response body, nil when no response was received.
+	Body []byte
+	// Value is the destination the body was unmarshalled into (eg. *Response).
+	Value    interface{}
+	Duration time.Duration
+
+	// secrets are the query params holding the client's credential.
+	secrets []string
+}
+
+// Hooks are callbacks invoked around every request attempt, including retries,
+// made by the client and any service client built from it. Any callback may be nil.
+//
+// Request URLs contain the key; log them using Client.RedactURL, or the RoundTrip's String.
+type Hooks struct {
+	// OnRequest is called before the request is sent.
+	OnRequest func(req *http.Request)
+	// OnResponse is called after the response has been read and unmarshalled.
+	OnResponse func(rt RoundTrip)
+	// OnError is called when the attempt fails.
+	OnError func(rt RoundTrip, err error)
+}
+
+// WithHooks registers the given `h` callbacks. Hooks from multiple options are
+// invoked in the order they were given.
+func WithHooks(h Hooks) Option {
+	return func(c *Client) {
+		c.hooks = append(c.hooks, h)
+	}
+}
@@ -0,0 +1,128 @@
+// Package httpapi exposes address verification over HTTP.
+//
+// The handler accepts a POSTed JSON melissa.AddressRequest, responding with the JSON
+// melissa.Result, or a JSON array of requests, responding with an array of results.
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/juztin/melissa"
+)
+
+// maxBodySize is the maximum accepted request body size.
+const maxBodySize = 1 << 20
+
+// Error is the JSON body returned when verification fails.
+type Error struct {
+	Error string
+	// Codes are the transmission codes reported by Melissa Data, if any.
+	Codes []string `json:",omitempty"`
+}
+
+// Handler is an http.Handler verifying addresses using a melissa.Verifier.
+type Handler struct {
+	verifier melissa.Verifier
+}
+
+// ServeHTTP implements http.Handler.
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		writeError(w, http.StatusMethodNotAllowed, errors.New(http.StatusText(http.StatusMethodNotAllowed)))
+		return
+	}
+	var body json.RawMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var v interface{}
+	var err error
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		var reqs []melissa.AddressRequest
+		if err = json.Unmarshal(body, &reqs); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		var rs []melissa.Result
+		rs, err = h.verifier.VerifyBatch(r.Context(), reqs)
+		var pe *melissa.PartialError
+		if errors.As(err, &pe) {
+			// The failed records are omitted, identified by the RecordIDs of the others.
+			err = nil
+		}
+		v = rs
+	} else {
+		var req melissa.AddressRequest
+		if err = json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		v, err = h.verifier.Verify(r.Context(), req)
+	}
+	if err != nil {
+		writeError(w, StatusCode(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+// StatusCode returns the HTTP status code best describing the verification error `err`.
+// Invalid input and rejected premium options are client errors, while an open circuit
+// breaker or an exhausted cost budget make the service unavailable.
+func StatusCode(err error) int {
+	var ve melissa.ValidationErrors
+	var te melissa.TransmissionError
+	switch {
+	case errors.As(err, &ve), errors.Is(err, melissa.ErrNoRecords):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, melissa.ErrPremiumRejected):
+		return http.StatusBadRequest
+	case errors.Is(err, melissa.ErrCircuitOpen), errors.Is(err, melissa.ErrBudgetExceeded):
+		return http.StatusServiceUnavailable
+	case errors.As(err, &te):
+		for _, code := range te.Codes {
+			if code == "GE03" {
+				return http.StatusRequestEntityTooLarge
+			}
+		}
+		return http.StatusBadGateway
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// writeError writes `err` as an Error, setting Retry-After when it's known when to retry.
+func writeError(w http.ResponseWriter, status int, err error) {
+	e := Error{Error: err.Error()}
+	var te melissa.TransmissionError
+	if errors.As(err, &te) {
+		e.Codes = te.Codes
+	}
+	var ce melissa.CircuitOpenError
+	if errors.As(err, &ce) && ce.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(ce.RetryAfter.Seconds()))))
+	}
+	writeJSON(w, status, e)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// New returns a new Handler verifying addresses using `v`.
+func New(v melissa.Verifier) Handler {
+	return Handler{v}
+}
@@ -0,0 +1,69 @@
+package melissa
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithDedupeWindow coalesces concurrent Verify calls for the same (normalized) address into
+// a single request, and reuses its successful result for identical calls made within `ttl`
+// of it completing, so retried lookups aren't billed twice. Failures aren't reused.
+func WithDedupeWindow(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.coalescer = &coalescer{ttl: ttl, calls: map[string]*call{}}
+	}
+}
+
+// coalescer suppresses duplicate calls, keyed by normalized request.
+type coalescer struct {
+	ttl   time.Duration
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// call is an in-flight, or recently completed, call.
+type call struct {
+	done chan struct{}
+	res  Result
+	err  error
+}
+
+// do returns the result of `fn`, calling it only when there isn't an in-flight or
+// recent call for `key`. Results shared between callers must not be modified.
+func (c *coalescer) do(ctx context.Context, key string, fn func() (Result, error)) (Result, error) {
+	c.mu.Lock()
+	cl, ok := c.calls[key]
+	if !ok {
+		cl = &call{done: make(chan struct{})}
+		c.calls[key] = cl
+	}
+	c.mu.Unlock()
+
+	if ok {
+		select {
+		case <-cl.done:
+			return cl.res, cl.err
+		case <-ctx.Done():
+			return Result{}, ctx.Err()
+		}
+	}
+
+	cl.res, cl.err = fn()
+	close(cl.done)
+	if cl.err != nil || c.ttl <= 0 {
+		c.forget(key, cl)
+	} else {
+		time.AfterFunc(c.ttl, func() { c.forget(key, cl) })
+	}
+	return cl.res, cl.err
+}
+
+// forget removes the call `cl` for `key`, unless it was already replaced.
+func (c *coalescer) forget(key string, cl *call) {
+	c.mu.Lock()
+	if c.calls[key] == cl {
+		delete(c.calls, key)
+	}
+	c.mu.Unlock()
+}
@@ -0,0 +1,100 @@
+// Package ip is a simple wrapper around Melissa Data's Global IP Locator service.
+package ip
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/juztin/melissa"
+)
+
+const globalIPURL = "https://globalip.melissadata.net/v4/WEB/iplocation/doiplocation"
+
+// ErrNoRecords is returned when the service doesn't return a record for an IP.
+var ErrNoRecords = errors.New("no records returned")
+
+var (
+	// Result code mappings
+	ResultCodes = map[string]string{
+		"IS01": "IP Address Found",
+		"IS02": "IP Address Found, Proxy Detected",
+
+		"IE01": "Invalid IP Address",
+		"IE02": "IP Address Not Found",
+		"IE03": "Private or Reserved IP Address",
+	}
+)
+
+// Client used to communicate with Melissa Data's Global IP Locator service.
+type Client struct {
+	client melissa.Client
+	urlStr string
+}
+
+// Melissa Data Global IP response type mapping
+type Response struct {
+	Records               []Record
+	TotalRecords          string
+	TransmissionReference string
+	TransmissionResults   string
+	Version               string
+}
+
+// Melissa Data Global IP record type mapping
+type Record struct {
+	City                string
+	ConnectionSpeed     string
+	ConnectionType      string
+	Continent           string
+	CountryAbbreviation string
+	CountryName         string
+	DomainAge           string
+	DomainName          string
+	IPAddress           string
+	ISPName             string
+	Latitude            string
+	Longitude           string
+	PostalCode          string
+	ProxyDescription    string
+	ProxyType           string
+	RecordID            string
+	Region              string
+	Results             string
+	UTC                 string
+}
+
+// IsProxy returns whether the IP was identified as a proxy.
+func (r Record) IsProxy() bool {
+	return r.ProxyType != ""
+}
+
+// Locate returns the location record for the given `ip` address.
+func (c Client) Locate(ctx context.Context, ip string) (Record, error) {
+	resp, err := c.Query(ctx, ip)
+	if err != nil {
+		return Record{}, err
+	}
+	if len(resp.Records) == 0 {
+		return Record{}, ErrNoRecords
+	}
+	return resp.Records[0], nil
+}
+
+// Query invokes a JSON GET request for the location of the given `ip` address.
+func (c Client) Query(ctx context.Context, ip string) (Response, error) {
+	var resp Response
+	qs := url.Values{}
+	qs.Set("ip", ip)
+	err := c.client.Get(ctx, c.urlStr, qs, &resp)
+	return resp, err
+}
+
+// NewClient returns a new Global IP client which uses `c` for communication,
+// sharing its key and request configuration.
+func NewClient(c melissa.Client) Client {
+	return Client{
+		client: c,
+		urlStr: c.URL(melissa.ServiceGlobalIP, globalIPURL),
+	}
+}
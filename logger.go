@@ -0,0 +1,65 @@
+package melissa
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WithLogger logs requests, retries, transmission errors and parse failures to `l`,
+// with the key redacted from all logged URLs and errors.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// logRequest logs the start of a request attempt.
+func (c Client) logRequest(req *http.Request) {
+	c.logger.LogAttrs(req.Context(), slog.LevelDebug, "melissa request",
+		slog.String("method", req.Method),
+		slog.String("url", c.RedactURL(req.URL)),
+		slog.String("reference", TransmissionReference(req.Context())),
+	)
+}
+
+// logResponse logs the outcome of a request attempt.
+func (c Client) logResponse(rt RoundTrip, err error) {
+	ctx := rt.Request.Context()
+	attrs := []slog.Attr{
+		slog.String("reference", TransmissionReference(ctx)),
+		slog.Duration("duration", rt.Duration),
+	}
+	if rt.Response != nil {
+		attrs = append(attrs, slog.Int("status", rt.Response.StatusCode))
+	}
+
+	var se StatusError
+	var ue *url.Error
+	r, _ := rt.Value.(*Response)
+	switch {
+	case errors.As(err, &se), errors.As(err, &ue):
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "melissa request failed", append(attrs, slog.String("error", err.Error()))...)
+	case err != nil:
+		c.logger.LogAttrs(ctx, slog.LevelError, "melissa response parse failed", append(attrs, slog.String("error", err.Error()))...)
+	case r != nil && r.TransmissionResults != "":
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "melissa transmission error", append(attrs, slog.String("codes", r.TransmissionResults))...)
+	case r != nil:
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "melissa response", append(attrs, slog.Int("records", len(r.Records)))...)
+	default:
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "melissa response", attrs...)
+	}
+}
+
+// logRetry logs that a failed request will be retried after `wait`.
+func (c Client) logRetry(ctx context.Context, attempt int, wait time.Duration, err error) {
+	c.logger.LogAttrs(ctx, slog.LevelInfo, "melissa retrying request",
+		slog.String("reference", TransmissionReference(ctx)),
+		slog.Int("attempt", attempt+1),
+		slog.Duration("wait", wait),
+		slog.String("error", err.Error()),
+	)
+}
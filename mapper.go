@@ -0,0 +1,128 @@
+package melissa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNotStruct is returned when mapping a value which isn't a pointer to a struct.
+var ErrNotStruct = errors.New("not a pointer to a struct")
+
+// outputAliases are the Record fields written back for AddressRequest fields of another name.
+var outputAliases = map[string]string{
+	"Country": "CountryISO3166_1_Alpha2",
+}
+
+// taggedField is a string field of a user struct tagged with a melissa field name.
+type taggedField struct {
+	index   []int
+	name    string
+	in, out bool
+}
+
+// VerifyStruct verifies the address held within the struct pointed to by `ptr` using `v`,
+// writing the verified values back into it unless verification failed.
+//
+// String fields are mapped using `melissa` struct tags naming an AddressRequest field, which
+// is sent, and the Record field of the same name, which is written back (eg.
+// `melissa:"AddressLine1"`). Country is written back from CountryISO3166_1_Alpha2.
+// Fields may be suffixed with ",in" to only be sent, or ",out" to only be written back
+// (eg. `melissa:"Latitude,out"`).
+func VerifyStruct(ctx context.Context, v Verifier, ptr interface{}) (Result, error) {
+	r, err := StructRequest(ptr)
+	if err != nil {
+		return Result{}, err
+	}
+	res, err := v.Verify(ctx, r)
+	if err != nil || res.Outcome == Failed {
+		return res, err
+	}
+	return res, WriteStruct(ptr, res.Record)
+}
+
+// StructRequest returns the AddressRequest held by the `melissa` tagged fields of the struct
+// pointed to by `ptr`, as described by VerifyStruct.
+func StructRequest(ptr interface{}) (AddressRequest, error) {
+	var r AddressRequest
+	sv, fields, err := taggedFields(ptr)
+	if err != nil {
+		return r, err
+	}
+	rv := reflect.ValueOf(&r).Elem()
+	for _, f := range fields {
+		if !f.in {
+			continue
+		}
+		dst := rv.FieldByName(f.name)
+		if !dst.IsValid() || dst.Kind() != reflect.String {
+			return r, fmt.Errorf("invalid melissa tag, unknown AddressRequest field %q", f.name)
+		}
+		dst.SetString(sv.FieldByIndex(f.index).String())
+	}
+	return r, nil
+}
+
+// WriteStruct writes the fields of `rec` into the `melissa` tagged fields of the struct
+// pointed to by `ptr`, as described by VerifyStruct.
+func WriteStruct(ptr interface{}, rec Record) error {
+	sv, fields, err := taggedFields(ptr)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(rec)
+	for _, f := range fields {
+		if !f.out {
+			continue
+		}
+		name := f.name
+		if alias, ok := outputAliases[name]; ok {
+			name = alias
+		}
+		src := rv.FieldByName(name)
+		if !src.IsValid() || src.Kind() != reflect.String {
+			if f.in {
+				// Input only fields, without a record field, aren't written back.
+				continue
+			}
+			return fmt.Errorf("invalid melissa tag, unknown Record field %q", f.name)
+		}
+		sv.FieldByIndex(f.index).SetString(src.String())
+	}
+	return nil
+}
+
+// taggedFields returns the struct pointed to by `ptr` and its `melissa` tagged fields.
+func taggedFields(ptr interface{}) (reflect.Value, []taggedField, error) {
+	pv := reflect.ValueOf(ptr)
+	if pv.Kind() != reflect.Ptr || pv.IsNil() || pv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, nil, ErrNotStruct
+	}
+	sv := pv.Elem()
+	var fields []taggedField
+	for _, sf := range reflect.VisibleFields(sv.Type()) {
+		tag, ok := sf.Tag.Lookup("melissa")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		if sf.Type.Kind() != reflect.String {
+			return sv, nil, fmt.Errorf("invalid melissa tag on %s, field isn't a string", sf.Name)
+		}
+		parts := strings.Split(tag, ",")
+		f := taggedField{index: sf.Index, name: parts[0], in: true, out: true}
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "in":
+				f.out = false
+			case "out":
+				f.in = false
+			default:
+				return sv, nil, fmt.Errorf("invalid melissa tag on %s, unknown option %q", sf.Name, opt)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return sv, fields, nil
+}
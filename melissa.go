@@ -2,11 +2,19 @@
 package melissa
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 const globalAddressURL = "https://address.melissadata.net/v3/WEB/GlobalAddress/doGlobalAddress"
@@ -23,6 +31,7 @@ var (
 		"GE06": "disabled CustomerID",
 		"GE07": http.StatusText(http.StatusBadRequest),
 		"GE08": "invalid CustomerID for product",
+		"GE14": "out of credits",
 	}
 	// Result code mappings
 	ResultCodes = map[string]string{
@@ -51,6 +60,17 @@ var (
 		"AC15": "DoubleDependent Locality",
 		"AC16": "SubAdministrative Area",
 		"AC17": "SubNational Area",
+
+		"AV11": "Partially Verified to Administrative Area",
+		"AV12": "Partially Verified to Locality",
+		"AV13": "Partially Verified to Thoroughfare",
+		"AV14": "Partially Verified to Premises",
+		"AV15": "Partially Verified to SubPremises",
+		"AV21": "Verified to Administrative Area",
+		"AV22": "Verified to Locality",
+		"AV23": "Verified to Thoroughfare",
+		"AV24": "Verified to Premises",
+		"AV25": "Verified to SubPremises",
 	}
 	// Geocode mappings
 	GeoCodes = map[string]string{
@@ -90,18 +110,96 @@ var (
 
 // Client used to communicated with Melissa Data's GlobalAddress service.
 type Client struct {
-	client http.Client
-	urlStr string
-	key    string
+	client  http.Client
+	urlStr  string
+	cred    Credential
+	retries int
+	backoff time.Duration
+	timeout time.Duration
+	header  http.Header
+	hooks   []Hooks
+	metrics Metrics
+	logger  *slog.Logger
+	breaker *breaker
+	cache   *cacheTransport
+
+	coalescer  *coalescer
+	state      *clientState
+	cost       *CostTracker
+	auditStore AuditStore
+
+	sanitizers   []Sanitizer
+	processors   []Processor
+	retryPolicy  RetryPolicy
+	batchWorkers int
+	validate     bool
+	sandbox      bool
+	rawCapture   bool
+	gzip         bool
+	format       Format
+	profile      EndpointProfile
+
+	premium       PremiumOptions
+	premiumPolicy PremiumPolicy
+}
+
+// StatusError is returned when Melissa Data responds with a non-200 status code.
+type StatusError struct {
+	StatusCode int
+	Reference  string
+	// Body is the beginning of the response body.
+	Body string
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("invalid response code, %d, received for transmission %s", e.StatusCode, e.Reference)
+}
+
+// Is reports whether the response indicates exhausted credits or rate limiting,
+// matching ErrQuotaExceeded.
+func (e StatusError) Is(target error) bool {
+	if target != ErrQuotaExceeded {
+		return false
+	}
+	if e.StatusCode == http.StatusPaymentRequired || e.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	body := strings.ToLower(e.Body)
+	return strings.Contains(body, "credit") || strings.Contains(body, "quota")
 }
 
 // Melissa Data response type mapping
 type Response struct {
-	Records               []Record
+	Records               []Record `xml:"Records>ResponseRecord"`
 	TotalRecords          string
 	TransmissionReference string
 	TransmissionResults   string
 	Version               string
+	// Raw is the unmodified response body, populated only when the client
+	// was created using WithRawCapture.
+	Raw []byte `json:"-" xml:"-"`
+	// Extras are the fields returned by Melissa Data unknown to the client.
+	Extras map[string]json.RawMessage `json:"-" xml:"-"`
+
+	// each, when set, receives each record as it's decoded instead of Records.
+	each func(Record) error
+}
+
+// Err returns a TransmissionError when the response contains transmission results.
+func (r Response) Err() error {
+	if codes := splitCodes(r.TransmissionResults); len(codes) > 0 {
+		return TransmissionError{codes, r.TransmissionReference}
+	}
+	return nil
+}
+
+// ByRecordID returns the response records keyed by their RecordID.
+func (r Response) ByRecordID() map[string]Record {
+	m := make(map[string]Record, len(r.Records))
+	for _, rec := range r.Records {
+		m[rec.RecordID] = rec
+	}
+	return m
 }
 
 // Melissa Data record type mapping
@@ -117,11 +215,19 @@ type Record struct {
 	AddressLine8                       string
 	AddressType                        string
 	AdministrativeArea                 string
+	AdministrativeAreaLatin            string
+	AdministrativeAreaNative           string
 	Building                           string
+	CMRA                               string
+	CarrierRoute                       string
 	CountryISO3166_1_Alpha2            string
 	CountryISO3166_1_Alpha3            string
 	CountryISO3166_1_Numeric           string
 	CountryName                        string
+	DPVFootnotes                       string
+	DeliveryIndicator                  string
+	DeliveryPointCheckDigit            string
+	DeliveryPointCode                  string
 	DependentLocality                  string
 	DependentThoroughfare              string
 	DependentThoroughfareLeadingType   string
@@ -131,10 +237,15 @@ type Record struct {
 	DependentThoroughfareTrailingType  string
 	DoubleDependentLocality            string
 	FormattedAddress                   string
+	FormattedAddressLatin              string
+	FormattedAddressNative             string
 	Latitude                           string
 	Locality                           string
+	LocalityLatin                      string
+	LocalityNative                     string
 	Longitude                          string
 	Organization                       string
+	Plus4                              string
 	PostBox                            string
 	PostalCode                         string
 	PremisesNumber                     string
@@ -152,61 +263,426 @@ type Record struct {
 	ThoroughfarePostDirection          string
 	ThoroughfarePreDirection           string
 	ThoroughfareTrailingType           string
+
+	// Extras are the fields returned by Melissa Data unknown to the client.
+	Extras map[string]json.RawMessage `json:"-" xml:"-"`
+}
+
+// HealthStatus is the result of a Ping.
+type HealthStatus struct {
+	Reachable  bool
+	StatusCode int
+	Latency    time.Duration
+	// Version is the service version reported by the response, when there is one.
+	Version string
 }
 
-// Ping simply hits the base URL for the GlobalAddress endpoint to ensure there is connectivity.
-func (c Client) Ping() error {
-	resp, err := http.Get(globalAddressURL)
+// Ping simply hits the base URL for the GlobalAddress endpoint, using the given HTTP `method`
+// (GET when empty, or HEAD), to ensure there is connectivity and to measure its latency.
+func (c Client) Ping(ctx context.Context, method string) (HealthStatus, error) {
+	var h HealthStatus
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.urlStr, nil)
 	if err != nil {
-		return err
+		return h, err
+	}
+	c.setHeaders(req)
+	start := time.Now()
+	resp, err := c.httpClient().Do(req)
+	h.Latency = time.Since(start)
+	if err != nil {
+		return h, redactError(err, c.secretParams())
 	}
 	defer resp.Body.Close()
+	var v struct{ Version string }
+	json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&v)
+	io.Copy(ioutil.Discard, resp.Body)
+
+	h.StatusCode = resp.StatusCode
+	h.Version = v.Version
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("invalid response code, %d, received for ping", resp.StatusCode)
+		return h, fmt.Errorf("invalid response code, %d, received for ping", resp.StatusCode)
 	}
-	return nil
+	h.Reachable = true
+	return h, nil
 }
 
-// Query invokes a JSON request to Melissa data using the given `qs` url.Values
-// as the query params. A populated Response object is returned only when there are no errors.
-func (c Client) Query(qs url.Values) (Response, error) {
+// Key returns the private key used to authenticate with Melissa Data.
+func (c Client) Key() string {
+	return c.cred.Key()
+}
+
+// Get invokes a JSON GET request against `urlStr` using the given `qs` url.Values
+// as the query params, unmarshalling the response body into `v`.
+// The key and TransmissionReference are added to the query params.
+func (c Client) Get(ctx context.Context, urlStr string, qs url.Values, v interface{}) error {
+	req, err := c.newGet(ctx, urlStr, qs, FormatJSON)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, v)
+	return err
+}
+
+// Post invokes a JSON POST request against `urlStr` using `body` as the JSON payload,
+// unmarshalling the response body into `v`. Payloads should embed a Transmission, given
+// as a pointer, to have the client populate the key and TransmissionReference.
+func (c Client) Post(ctx context.Context, urlStr string, body interface{}, v interface{}) error {
+	req, err := c.newPost(ctx, urlStr, body, FormatJSON)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, v)
+	return err
+}
+
+// newGet returns a new GET request against `urlStr` using the given `qs` as the query params,
+// requesting a response in the given format. SOAP requests are POSTed, as single record batches.
+// The caller's `qs` are left unmodified, so may be reused or shared between goroutines.
+func (c Client) newGet(ctx context.Context, urlStr string, qs url.Values, f Format) (*http.Request, error) {
+	if f == FormatSOAP {
+		return c.newSOAPQuery(ctx, urlStr, qs)
+	}
+	qs = cloneValues(qs)
+	if ref := qs.Get("t"); ref != "" {
+		ctx = ContextWithTransmissionReference(ctx, ref)
+	} else {
+		var ref string
+		ctx, ref = ensureReference(ctx)
+		qs.Set("t", ref)
+	}
+	if f == FormatXML {
+		qs.Set("format", "xml")
+	}
+	h := http.Header{}
+	c.cred.Authenticate(qs, h)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s?%s", urlStr, qs.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = h
+	req.Header.Add("Accept", f.contentType())
+	c.setHeaders(req)
+	return req, nil
+}
+
+// cloneValues returns a copy of `qs`.
+func cloneValues(qs url.Values) url.Values {
+	c := make(url.Values, len(qs))
+	for k, vs := range qs {
+		c[k] = append([]string(nil), vs...)
+	}
+	return c
+}
+
+// newPost returns a new POST request against `urlStr` using `body`, encoded in the given format,
+// as the payload. When `body` embeds a Transmission it's populated with the key and TransmissionReference.
+func (c Client) newPost(ctx context.Context, urlStr string, body interface{}, f Format) (*http.Request, error) {
+	if t, ok := body.(transmitter); ok {
+		var ref string
+		ctx, ref = ensureReference(ctx)
+		t.transmission().CustomerID = c.cred.Key()
+		t.transmission().TransmissionReference = ref
+	}
+	data, err := f.marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	if c.gzip {
+		if data, err = compress(data); err != nil {
+			return nil, err
+		}
+	}
+	ctx = withRecords(ctx, recordCount(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", urlStr, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	c.cred.Authenticate(nil, req.Header)
+	req.Header.Add("Content-Type", f.contentType())
+	req.Header.Add("Accept", f.contentType())
+	if f == FormatSOAP {
+		req.Header.Set("SOAPAction", soapAction)
+	}
+	if c.gzip {
+		req.Header.Add("Content-Encoding", "gzip")
+	}
+	c.setHeaders(req)
+	return req, nil
+}
+
+// setHeaders sets the client's configured headers on `req`.
+func (c Client) setHeaders(req *http.Request) {
+	for k, vs := range c.header {
+		req.Header[k] = append([]string(nil), vs...)
+	}
+}
+
+// doResponse invokes the given GlobalAddress request, returning the unmarshalled response.
+func (c Client) doResponse(req *http.Request) (Response, error) {
 	var r Response
-	// Gets the query-string, excluding empty values from the address.
-	qs.Add("id", c.key)
-	urlStr := fmt.Sprintf("%s?%s", c.urlStr, qs.Encode())
-	req, err := http.NewRequest("GET", urlStr, nil)
+	body, err := c.do(req, &r)
+	if r.TransmissionReference == "" {
+		r.TransmissionReference = TransmissionReference(req.Context())
+	}
+	if c.rawCapture {
+		r.Raw = body
+	}
+	if err == nil {
+		err = c.auditResponse(req, r)
+	}
+	return r, err
+}
+
+// do invokes the given request, retrying transient failures as configured,
+// and unmarshalling the response body into `v`. The raw body of the final attempt is returned.
+func (c Client) do(req *http.Request, v interface{}) ([]byte, error) {
+	if d := c.callTimeout(req.Context()); d > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), d)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	if c.gzip {
+		req.Header.Add("Accept-Encoding", "gzip")
+	}
+	for attempt := 0; ; attempt++ {
+		if c.breaker != nil && !c.breaker.allow() {
+			return nil, CircuitOpenError{RetryAfter: c.breaker.retryAfter()}
+		}
+		body, err := c.attempt(req, v)
+		if c.breaker != nil {
+			c.breaker.observe(err)
+		}
+		a := newAttempt(attempt, v, err)
+		if err == nil && len(a.Codes) == 0 || attempt >= c.retries || !c.retryPolicy.Retry(a) {
+			return body, err
+		}
+		if r, ok := v.(*Response); ok && err == nil {
+			*r = Response{}
+		}
+		if c.metrics != nil {
+			c.metrics.ObserveRetry()
+		}
+
+		// Wait before the next attempt, doubling the wait each time.
+		wait := c.backoff << uint(attempt)
+		if c.logger != nil {
+			cause := err
+			if cause == nil {
+				cause = TransmissionError{a.Codes, TransmissionReference(req.Context())}
+			}
+			c.logRetry(req.Context(), attempt, wait, cause)
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			t.Stop()
+			return body, err
+		case <-t.C:
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// attempt invokes a single round-trip of `req`, unmarshalling the response body into `v`.
+// Attempts exceeding a cost budget fail before any hooks are called, as they aren't sent.
+func (c Client) attempt(req *http.Request, v interface{}) ([]byte, error) {
+	costs, n, err := c.reserveCost(req)
 	if err != nil {
-		return r, err
+		return nil, err
+	}
+	for _, h := range c.hooks {
+		if h.OnRequest != nil {
+			h.OnRequest(req)
+		}
+	}
+	if c.logger != nil {
+		c.logRequest(req)
+	}
+	start := time.Now()
+	rt, err := c.roundTrip(req, v)
+	if rt.Response != nil && rt.Response.StatusCode == http.StatusOK {
+		for _, t := range costs {
+			t.bill(n)
+		}
+	}
+	rt.Duration = time.Since(start)
+	if c.state != nil {
+		c.state.record(rt.Response, err)
+	}
+	if c.metrics != nil {
+		c.observe(rt, err)
+	}
+	if c.logger != nil {
+		c.logResponse(rt, err)
+	}
+	for _, h := range c.hooks {
+		if err != nil && h.OnError != nil {
+			h.OnError(rt, err)
+		} else if err == nil && h.OnResponse != nil {
+			h.OnResponse(rt)
+		}
 	}
+	return rt.Body, err
+}
+
+// httpClient returns the HTTP client used to send requests, using the sandbox or cache when configured.
+func (c Client) httpClient() *http.Client {
+	client := c.client
+	if c.sandbox {
+		client.Transport = sandboxTransport{c.urlStr}
+	} else if c.cache != nil {
+		client.Transport = c.cache.wrap(client.Transport, c.secretParams())
+	}
+	return &client
+}
 
-	// Invoke a JSON request.
-	req.Header.Add("Accept", "application/json")
-	resp, err := c.client.Do(req)
+// roundTrip sends `req` and reads the response, unmarshalling the body into `v`.
+func (c Client) roundTrip(req *http.Request, v interface{}) (RoundTrip, error) {
+	rt := RoundTrip{Request: req, Value: v, secrets: c.secretParams()}
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
-		return r, err
+		return rt, redactError(err, rt.secrets)
 	}
 	defer resp.Body.Close()
+	rt.Response = resp
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return rt, StatusError{resp.StatusCode, TransmissionReference(req.Context()), string(snippet)}
+	}
 
-	// TODO check response status code for 200
-	data, err := ioutil.ReadAll(resp.Body)
+	// Only buffer the body when something needs the raw bytes.
+	var body io.Reader = resp.Body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return rt, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+	var buf *bytes.Buffer
+	if c.rawCapture || len(c.hooks) > 0 {
+		buf = &bytes.Buffer{}
+		body = io.TeeReader(body, buf)
+	}
+
+	// Read and transform data, draining the remainder so the connection can be reused.
+	f := formatOf(req.Header.Get("Accept"))
+	if r, ok := v.(*Response); ok && r.each != nil {
+		err = r.decodeStream(body, f)
+	} else {
+		err = f.decode(body, v)
+	}
+	if err == nil {
+		_, err = io.Copy(ioutil.Discard, body)
+	}
+	if buf != nil {
+		rt.Body = buf.Bytes()
+	}
+	return rt, err
+}
+
+// compress returns the gzip compressed `data`.
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// retryable returns whether the given error, returned from a request, is transient.
+func retryable(err error) bool {
+	var se StatusError
+	if errors.As(err, &se) {
+		return se.StatusCode >= http.StatusInternalServerError
+	}
+	var ue *url.Error
+	return errors.As(err, &ue) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) &&
+		!errors.Is(err, ErrCacheMiss)
+}
+
+// Query invokes a JSON request to Melissa data using the given `qs` url.Values
+// as the query params. A populated Response object is returned only when there are no errors.
+func (c Client) Query(qs url.Values) (Response, error) {
+	return c.QueryContext(context.Background(), qs)
+}
+
+// QueryContext is like Query, using `ctx` for the lifetime of the request,
+// configured by the given call `opts`.
+func (c Client) QueryContext(ctx context.Context, qs url.Values, opts ...CallOption) (Response, error) {
+	req, err := c.newQuery(ContextWithCallOptions(ctx, opts...), qs)
 	if err != nil {
-		return r, err
+		return Response{}, err
 	}
+	return c.doResponse(req)
+}
 
-	// Read and transform data.
-	err = json.Unmarshal(data, &r)
+// QueryBatch invokes a JSON POST request to Melissa data for all of the given `records`.
+// Records without a RecordID are assigned their one-based position within `records`,
+// for use with Response.ByRecordID.
+// Options (eg. OutputScript) apply to the whole batch and are taken from the first record.
+//
+// Batches larger than MaxRecords are split into chunks, sent as configured by
+// WithBatchConcurrency, and merged in record order. When chunks fail, the records of the
+// successful chunks are returned along with ChunkErrors, and Response.Raw isn't populated.
+//
+// When some records come back with SE or GE codes, the successful records are returned
+// along with a *PartialError identifying the failed records.
+func (c Client) QueryBatch(ctx context.Context, records []AddressRequest, opts ...CallOption) (Response, error) {
+	resp, err := c.queryRecords(ContextWithCallOptions(ctx, opts...), c.sanitize(records))
+	if err == nil && resp.Err() == nil {
+		resp, err = partialError(resp)
+	}
+	return resp, err
+}
+
+// queryRecords sends the sanitized `records`, split into chunks when larger than MaxRecords.
+func (c Client) queryRecords(ctx context.Context, records []AddressRequest) (Response, error) {
+	if len(records) > MaxRecords {
+		return c.queryChunks(ctx, records)
+	}
+	return c.queryBatch(ctx, records)
+}
+
+// queryBatch sends `records` within a single batch request.
+func (c Client) queryBatch(ctx context.Context, records []AddressRequest) (Response, error) {
+	req, err := c.newBatch(ctx, newBatchRequest(records))
 	if err != nil {
-		return r, err
+		return Response{}, err
 	}
-	return r, err
+	return c.doResponse(req)
 }
 
-// NewClient returns a new client using the given `apiKey` as the private key.
-func NewClient(apiKey string) Client {
-	client := http.Client{}
-	return Client{
-		client: client,
-		urlStr: globalAddressURL,
-		key:    apiKey,
+// NewClient returns a new client using the given `apiKey` as the private key,
+// configured by the given `opts`.
+func NewClient(apiKey string, opts ...Option) Client {
+	client := http.Client{Transport: newTransport()}
+	c := Client{
+		client:      client,
+		urlStr:      globalAddressURL,
+		cred:        CustomerID(apiKey),
+		state:       newClientState(),
+		retryPolicy: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.format == FormatSOAP && c.urlStr == globalAddressURL {
+		c.urlStr = globalAddressSOAPURL
 	}
+	c.urlStr = c.URL(ServiceGlobalAddress, c.urlStr)
+	return c
 }
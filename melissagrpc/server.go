@@ -0,0 +1,162 @@
+// Package melissagrpc implements the melissa.v1.AddressVerification gRPC service, defined
+// within proto/melissa/v1/melissa.proto, backed by a melissa.Client.
+//
+// Register the server using the generated melissapb package:
+//
+//	melissapb.RegisterAddressVerificationServer(s, melissagrpc.NewServer(client))
+package melissagrpc
+
+//go:generate protoc -I ../proto --go_out=. --go_opt=module=github.com/juztin/melissa/melissagrpc --go-grpc_out=. --go-grpc_opt=module=github.com/juztin/melissa/melissagrpc melissa/v1/melissa.proto
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/juztin/melissa"
+	"github.com/juztin/melissa/melissagrpc/melissapb"
+)
+
+// Backend verifies and autocompletes addresses. melissa.Client implements Backend.
+type Backend interface {
+	melissa.Verifier
+	Autocomplete(ctx context.Context, query, country string, max int) ([]string, error)
+}
+
+var _ Backend = melissa.Client{}
+
+// Server implements melissapb.AddressVerificationServer.
+type Server struct {
+	melissapb.UnimplementedAddressVerificationServer
+	backend Backend
+}
+
+// VerifyAddress implements melissapb.AddressVerificationServer.
+func (s Server) VerifyAddress(ctx context.Context, req *melissapb.VerifyAddressRequest) (*melissapb.VerifyAddressResponse, error) {
+	if req.GetAddress() == nil {
+		return nil, status.Error(codes.InvalidArgument, "address is required")
+	}
+	res, err := s.backend.Verify(ctx, fromAddress(req.GetAddress()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &melissapb.VerifyAddressResponse{Result: toResult(res)}, nil
+}
+
+// VerifyBatch implements melissapb.AddressVerificationServer.
+func (s Server) VerifyBatch(ctx context.Context, req *melissapb.VerifyBatchRequest) (*melissapb.VerifyBatchResponse, error) {
+	if len(req.GetAddresses()) > melissa.MaxRecords {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d addresses may be verified", melissa.MaxRecords)
+	}
+	reqs := make([]melissa.AddressRequest, len(req.GetAddresses()))
+	for i, a := range req.GetAddresses() {
+		reqs[i] = fromAddress(a)
+	}
+	rs, err := s.backend.VerifyBatch(ctx, reqs)
+	// The records of a PartialError are omitted, identified by the RecordIDs of the others.
+	var pe *melissa.PartialError
+	if err != nil && !errors.As(err, &pe) {
+		return nil, toStatus(err)
+	}
+	resp := &melissapb.VerifyBatchResponse{Results: make([]*melissapb.Result, len(rs))}
+	for i, r := range rs {
+		resp.Results[i] = toResult(r)
+	}
+	return resp, nil
+}
+
+// Autocomplete implements melissapb.AddressVerificationServer.
+func (s Server) Autocomplete(ctx context.Context, req *melissapb.AutocompleteRequest) (*melissapb.AutocompleteResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	suggestions, err := s.backend.Autocomplete(ctx, req.GetQuery(), req.GetCountry(), int(req.GetMaxResults()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &melissapb.AutocompleteResponse{Suggestions: suggestions}, nil
+}
+
+func fromAddress(a *melissapb.Address) melissa.AddressRequest {
+	return melissa.AddressRequest{
+		RecordID:                a.GetRecordId(),
+		Organization:            a.GetOrganization(),
+		AddressLine1:            a.GetAddressLine1(),
+		AddressLine2:            a.GetAddressLine2(),
+		AddressLine3:            a.GetAddressLine3(),
+		AddressLine4:            a.GetAddressLine4(),
+		AddressLine5:            a.GetAddressLine5(),
+		AddressLine6:            a.GetAddressLine6(),
+		AddressLine7:            a.GetAddressLine7(),
+		AddressLine8:            a.GetAddressLine8(),
+		DoubleDependentLocality: a.GetDoubleDependentLocality(),
+		DependentLocality:       a.GetDependentLocality(),
+		Locality:                a.GetLocality(),
+		SubAdministrativeArea:   a.GetSubAdministrativeArea(),
+		AdministrativeArea:      a.GetAdministrativeArea(),
+		PostalCode:              a.GetPostalCode(),
+		SubNationalArea:         a.GetSubNationalArea(),
+		Country:                 a.GetCountry(),
+	}
+}
+
+func toResult(r melissa.Result) *melissapb.Result {
+	return &melissapb.Result{
+		RecordId:           r.RecordID,
+		Outcome:            melissapb.Outcome(r.Outcome),
+		Results:            r.Codes(),
+		Corrections:        r.Corrections,
+		Errors:             r.Errors,
+		FormattedAddress:   r.FormattedAddress,
+		Organization:       r.Organization,
+		AddressLine1:       r.AddressLine1,
+		AddressLine2:       r.AddressLine2,
+		Locality:           r.Locality,
+		AdministrativeArea: r.AdministrativeArea,
+		PostalCode:         r.PostalCode,
+		CountryIso2:        r.CountryISO3166_1_Alpha2,
+		Latitude:           r.Latitude,
+		Longitude:          r.Longitude,
+		AddressKey:         r.AddressKey,
+	}
+}
+
+// toStatus converts the verification error `err` into a gRPC status error.
+func toStatus(err error) error {
+	var ve melissa.ValidationErrors
+	var te melissa.TransmissionError
+	switch {
+	case errors.As(err, &ve):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, melissa.ErrInvalidKey), errors.Is(err, melissa.ErrAccountDisabled),
+		errors.Is(err, melissa.ErrPremiumRejected):
+		// These describe the server's own Melissa credentials, not the caller's.
+		return status.Error(codes.Internal, err.Error())
+	case errors.Is(err, melissa.ErrQuotaExceeded), errors.Is(err, melissa.ErrBudgetExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, melissa.ErrNoRecords):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &te):
+		for _, code := range te.Codes {
+			switch code {
+			case "GE01", "GE02", "GE03", "GE07":
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+// NewServer returns a new Server verifying and autocompleting addresses using `b`,
+// typically a melissa.Client.
+func NewServer(b Backend) Server {
+	return Server{backend: b}
+}
@@ -0,0 +1,69 @@
+// Package melissaprom reports melissa client metrics to Prometheus.
+package melissaprom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juztin/melissa"
+)
+
+// Metrics is a melissa.Metrics implementation backed by Prometheus collectors.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	records  *prometheus.CounterVec
+	retries  prometheus.Counter
+}
+
+var _ melissa.Metrics = (*Metrics)(nil)
+
+// ObserveRequest implements melissa.Metrics.
+func (m *Metrics) ObserveRequest(outcome string, d time.Duration) {
+	m.requests.WithLabelValues(outcome).Inc()
+	m.duration.WithLabelValues(outcome).Observe(d.Seconds())
+}
+
+// ObserveRecord implements melissa.Metrics.
+func (m *Metrics) ObserveRecord(level string) {
+	if level == "" {
+		level = "none"
+	}
+	m.records.WithLabelValues(level).Inc()
+}
+
+// ObserveRetry implements melissa.Metrics.
+func (m *Metrics) ObserveRetry() {
+	m.retries.Inc()
+}
+
+// New returns Metrics whose collectors are registered with `reg`.
+// Use prometheus.DefaultRegisterer to expose them on the default registry.
+func New(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "melissa",
+			Name:      "requests_total",
+			Help:      "Total requests made to Melissa Data, by outcome.",
+		}, []string{"outcome"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "melissa",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of requests made to Melissa Data, by outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"outcome"}),
+		records: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "melissa",
+			Name:      "records_total",
+			Help:      "Total records returned by Melissa Data, by verification level.",
+		}, []string{"level"}),
+		retries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "melissa",
+			Name:      "retries_total",
+			Help:      "Total requests retried.",
+		}),
+	}
+	reg.MustRegister(m.requests, m.duration, m.records, m.retries)
+	return m
+}
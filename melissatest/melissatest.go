@@ -0,0 +1,131 @@
+// Package melissatest provides a fake GlobalAddress server for testing without live credentials.
+//
+// The server responds with canned fixtures selected by magic inputs:
+//
+//	key KeyEmpty / KeyInvalid / KeyDisabled   -> GE04 / GE05 / GE06 transmission errors
+//	AddressLine1 UnknownStreet                -> AE02 (unknown street)
+//	AddressLine1 MultipleMatches              -> AE05 (multiple matches)
+//	anything else                             -> verified, with a rooftop geocode
+package melissatest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/juztin/melissa"
+)
+
+const (
+	// Keys producing transmission errors.
+	KeyEmpty    = ""
+	KeyInvalid  = "invalid-key"
+	KeyDisabled = "disabled-key"
+
+	// AddressLine1 values producing record level errors.
+	UnknownStreet   = "1 Unknown St"
+	MultipleMatches = "100 Main St"
+
+	// Results returned for each fixture.
+	VerifiedResults        = "AV24,GS05"
+	UnknownStreetResults   = "AE02"
+	MultipleMatchesResults = "AE05"
+)
+
+// Server is a fake GlobalAddress server, responding in JSON, XML or SOAP.
+type Server struct {
+	*httptest.Server
+	// KeyParam and KeyHeader, when set, are the query param and header the key is read
+	// from when it isn't sent as the "id" or "license" query param, or as the CustomerID
+	// of a batch, matching the Param and Header of a melissa.LicenseKey.
+	KeyParam  string
+	KeyHeader string
+}
+
+// ServeHTTP responds to both single GET and batch POST GlobalAddress requests.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	t, records, err := melissa.ParseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	key := t.CustomerID
+	if key == "" && s.KeyParam != "" {
+		key = r.URL.Query().Get(s.KeyParam)
+	}
+	if key == "" && s.KeyHeader != "" {
+		key = r.Header.Get(s.KeyHeader)
+	}
+
+	resp := Respond(key, records)
+	resp.TransmissionReference = t.TransmissionReference
+	contentType, body, err := melissa.MarshalResponse(r, resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(body)
+}
+
+// Respond returns the canned response for the given `key` and `records`.
+func Respond(key string, records []melissa.AddressRequest) melissa.Response {
+	resp := melissa.Response{Version: "test"}
+	switch key {
+	case KeyEmpty:
+		resp.TransmissionResults = "GE04"
+	case KeyInvalid:
+		resp.TransmissionResults = "GE05"
+	case KeyDisabled:
+		resp.TransmissionResults = "GE06"
+	}
+	if resp.TransmissionResults != "" {
+		resp.TotalRecords = "0"
+		return resp
+	}
+
+	for _, r := range records {
+		resp.Records = append(resp.Records, Record(r))
+	}
+	resp.TotalRecords = strconv.Itoa(len(resp.Records))
+	return resp
+}
+
+// Record returns the canned record for the given address `r`, built upon the
+// melissa.SandboxRecord with the fixtures of this package.
+func Record(r melissa.AddressRequest) melissa.Record {
+	rec := melissa.SandboxRecord(r)
+	rec.Latitude, rec.Longitude = "", ""
+	switch r.AddressLine1 {
+	case UnknownStreet:
+		rec.Results = UnknownStreetResults
+	case MultipleMatches:
+		rec.Results = MultipleMatchesResults
+	default:
+		rec.Results = VerifiedResults
+		rec.Latitude = "38.897700"
+		rec.Longitude = "-77.036500"
+	}
+	return rec
+}
+
+// NewServer starts and returns a new fake GlobalAddress server.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewTestClient starts a fake server, closed when the test completes, and returns
+// a client using the given `key` which communicates with it.
+func NewTestClient(tb testing.TB, key string, opts ...melissa.Option) melissa.Client {
+	s := NewServer()
+	tb.Cleanup(s.Close)
+	return melissa.NewClient(key, append(opts, melissa.WithURL(s.URL))...)
+}
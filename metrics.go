@@ -0,0 +1,55 @@
+package melissa
+
+import (
+	"errors"
+	"time"
+)
+
+// Request outcomes reported to Metrics.
+const (
+	OutcomeSuccess           = "success"
+	OutcomeTransmissionError = "transmission_error"
+	OutcomeStatusError       = "status_error"
+	OutcomeError             = "error"
+)
+
+// Metrics receives measurements of the requests made by the client.
+// See the melissaprom package for a Prometheus implementation.
+type Metrics interface {
+	// ObserveRequest is called after every request attempt with its outcome and duration.
+	ObserveRequest(outcome string, d time.Duration)
+	// ObserveRecord is called for every returned GlobalAddress record with its
+	// verification (AV) level, or an empty string when it wasn't verified.
+	ObserveRecord(level string)
+	// ObserveRetry is called every time a request is retried.
+	ObserveRetry()
+}
+
+// WithMetrics reports measurements of every request made by the client to `m`.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// observe reports the given round-trip to the client's metrics.
+func (c Client) observe(rt RoundTrip, err error) {
+	r, _ := rt.Value.(*Response)
+	var se StatusError
+	outcome := OutcomeSuccess
+	switch {
+	case errors.As(err, &se):
+		outcome = OutcomeStatusError
+	case err != nil:
+		outcome = OutcomeError
+	case r != nil && r.TransmissionResults != "":
+		outcome = OutcomeTransmissionError
+	}
+	c.metrics.ObserveRequest(outcome, rt.Duration)
+
+	if r != nil && err == nil {
+		for _, rec := range r.Records {
+			c.metrics.ObserveRecord(rec.VerificationLevel())
+		}
+	}
+}
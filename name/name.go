@@ -0,0 +1,137 @@
+// Package name is a simple wrapper around Melissa Data's GlobalName service.
+package name
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/juztin/melissa"
+)
+
+const globalNameURL = "https://globalname.melissadata.net/v3/WEB/GlobalName/doGlobalName"
+
+// MaxRecords is the maximum number of records allowed in a single batch request.
+const MaxRecords = 100
+
+// ErrNoRecords is returned when the service doesn't return a record for a parsed name.
+var ErrNoRecords = errors.New("no records returned")
+
+var (
+	// Result code mappings
+	ResultCodes = map[string]string{
+		"NS01": "Parsing Successful",
+		"NS02": "Parsing Error",
+		"NS03": "First Name Spelling Corrected",
+		"NS04": "First Name 2 Spelling Corrected",
+		"NS05": "First Name 1 Found",
+		"NS06": "Last Name 1 Found",
+		"NS07": "First Name 2 Found",
+		"NS08": "Last Name 2 Found",
+
+		"NE01": "Unrecognized Format",
+		"NE02": "Multiple First Names Detected",
+		"NE03": "Vulgarity Detected",
+		"NE04": "Suspicious Word Detected",
+		"NE05": "Company Name Detected",
+		"NE06": "Non-Alphabetic Character Detected",
+	}
+	// Gender code mappings
+	GenderCodes = map[string]string{
+		"M": "Male",
+		"F": "Female",
+		"N": "Neutral",
+		"U": "Unknown",
+	}
+)
+
+// Client used to communicate with Melissa Data's GlobalName service.
+type Client struct {
+	client melissa.Client
+	urlStr string
+}
+
+// Request is a single name to be parsed.
+type Request struct {
+	RecordID string
+	Company  string
+	FullName string
+}
+
+// Melissa Data GlobalName response type mapping
+type Response struct {
+	Records               []Record
+	TotalRecords          string
+	TransmissionReference string
+	TransmissionResults   string
+	Version               string
+}
+
+// Melissa Data GlobalName record type mapping
+type Record struct {
+	RecordID    string
+	Results     string
+	Company     string
+	NamePrefix  string
+	NameFirst   string
+	NameMiddle  string
+	NameLast    string
+	NameSuffix  string
+	Gender      string
+	NamePrefix2 string
+	NameFirst2  string
+	NameMiddle2 string
+	NameLast2   string
+	NameSuffix2 string
+	Gender2     string
+	Salutation  string
+}
+
+// batchRequest is the JSON payload used for batch POST requests.
+type batchRequest struct {
+	melissa.Transmission
+	Records []Request
+}
+
+// Parse parses the given `fullName` into its components, returning the parsed record.
+func (c Client) Parse(ctx context.Context, fullName string) (Record, error) {
+	r, err := c.Query(ctx, Request{FullName: fullName})
+	if err != nil {
+		return Record{}, err
+	}
+	if len(r.Records) == 0 {
+		return Record{}, ErrNoRecords
+	}
+	return r.Records[0], nil
+}
+
+// Query invokes a JSON GET request to parse the single name within `r`.
+func (c Client) Query(ctx context.Context, r Request) (Response, error) {
+	var resp Response
+	qs := url.Values{}
+	if r.Company != "" {
+		qs.Set("comp", r.Company)
+	}
+	if r.FullName != "" {
+		qs.Set("full", r.FullName)
+	}
+	err := c.client.Get(ctx, c.urlStr, qs, &resp)
+	return resp, err
+}
+
+// QueryBatch invokes a JSON POST request to parse all of the given `records`.
+// At most MaxRecords may be sent per request.
+func (c Client) QueryBatch(ctx context.Context, records []Request) (Response, error) {
+	var resp Response
+	body := &batchRequest{Records: records}
+	err := c.client.Post(ctx, c.urlStr, body, &resp)
+	return resp, err
+}
+
+// NewClient returns a new GlobalName client which uses `c` for communication.
+func NewClient(c melissa.Client) Client {
+	return Client{
+		client: c,
+		urlStr: c.URL(melissa.ServiceGlobalName, globalNameURL),
+	}
+}
@@ -0,0 +1,59 @@
+package melissa
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Script is the script addresses are returned in.
+type Script string
+
+const (
+	// ScriptDefault returns addresses in the script they were given in.
+	ScriptDefault Script = ""
+	// ScriptLatin transliterates addresses into the Latin script.
+	ScriptLatin Script = "Latn"
+	// ScriptNative returns addresses in the native script of the country.
+	ScriptNative Script = "Native"
+)
+
+// Language is the language localities and administrative areas are returned in.
+type Language string
+
+const (
+	// LanguageDefault returns localities and administrative areas in the language they were given in.
+	LanguageDefault Language = ""
+	// LanguageEnglish returns English names (eg. "Munich") where Melissa Data knows them.
+	LanguageEnglish Language = "ENGLISH"
+	// LanguageNative returns names in the destination country's language (eg. "München").
+	LanguageNative Language = "NATIVE"
+)
+
+// requestOptions are the GlobalAddress options of a transmission (eg. OutputScript:Latn).
+type requestOptions map[string]string
+
+// String returns the options formatted as Melissa Data expects, sorted by name.
+func (o requestOptions) String() string {
+	opts := make([]string, 0, len(o))
+	for k, v := range o {
+		opts = append(opts, k+":"+v)
+	}
+	sort.Strings(opts)
+	return strings.Join(opts, ";")
+}
+
+// options returns the transmission options requested by `r`.
+func (r AddressRequest) options() requestOptions {
+	o := requestOptions{}
+	if r.OutputScript != ScriptDefault {
+		o["OutputScript"] = string(r.OutputScript)
+	}
+	if r.Language != LanguageDefault {
+		o["PreferredLanguage"] = string(r.Language)
+	}
+	if r.MaxSuggestions > 0 {
+		o["MaxSuggestions"] = strconv.Itoa(r.MaxSuggestions)
+	}
+	return o
+}
@@ -0,0 +1,78 @@
+// Package otelmelissa instruments melissa clients with OpenTelemetry tracing.
+package otelmelissa
+
+import (
+	"net/http"
+	"path"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/juztin/melissa"
+)
+
+const instrumentationName = "github.com/juztin/melissa/otelmelissa"
+
+// WithTracerProvider returns an option which records a client span, using `tp`, for
+// every transmission made by the client, including retries.
+// Spans are children of the span within the context given to the request.
+func WithTracerProvider(tp trace.TracerProvider) melissa.Option {
+	t := &tracer{tracer: tp.Tracer(instrumentationName)}
+	return melissa.WithHooks(melissa.Hooks{
+		OnRequest:  t.start,
+		OnResponse: t.end,
+		OnError:    t.fail,
+	})
+}
+
+// tracer tracks the in-flight span of each request.
+type tracer struct {
+	tracer trace.Tracer
+	spans  sync.Map
+}
+
+func (t *tracer) start(req *http.Request) {
+	_, span := t.tracer.Start(req.Context(), "melissa "+path.Base(req.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+		),
+	)
+	t.spans.Store(req, span)
+}
+
+func (t *tracer) end(rt melissa.RoundTrip) {
+	if span := t.span(rt); span != nil {
+		span.End()
+	}
+}
+
+func (t *tracer) fail(rt melissa.RoundTrip, err error) {
+	if span := t.span(rt); span != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+	}
+}
+
+// span removes and returns the span for the round-trip's request, annotated with its results.
+func (t *tracer) span(rt melissa.RoundTrip) trace.Span {
+	v, ok := t.spans.LoadAndDelete(rt.Request)
+	if !ok {
+		return nil
+	}
+	span := v.(trace.Span)
+	if rt.Response != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", rt.Response.StatusCode))
+	}
+	if r, ok := rt.Value.(*melissa.Response); ok && rt.Body != nil {
+		span.SetAttributes(
+			attribute.Int("melissa.record_count", len(r.Records)),
+			attribute.String("melissa.transmission_results", r.TransmissionResults),
+		)
+	}
+	return span
+}
@@ -0,0 +1,71 @@
+package melissa
+
+import "strings"
+
+// PostalAddress is a provider-agnostic postal address, modeled on schema.org's
+// PostalAddress and Google's i18n address model.
+type PostalAddress struct {
+	// Recipient is the name of the person the address belongs to, never set by Melissa Data.
+	Recipient    string
+	Organization string
+	// AddressLines are the delivery lines (eg. street and premises), excluding the
+	// locality, administrative area and postal code.
+	AddressLines []string
+	// Sublocality is the neighbourhood or district within the locality.
+	Sublocality        string
+	Locality           string
+	AdministrativeArea string
+	PostalCode         string
+	// Country is the ISO 3166-1 alpha-2 country code.
+	Country string
+}
+
+// ToPostalAddress converts the record to a PostalAddress.
+func (r Record) ToPostalAddress() PostalAddress {
+	a := PostalAddress{
+		Organization:       r.Organization,
+		Sublocality:        r.DependentLocality,
+		Locality:           r.Locality,
+		AdministrativeArea: r.AdministrativeArea,
+		PostalCode:         r.PostalCode,
+		Country:            r.CountryISO3166_1_Alpha2,
+	}
+	lines := []string{
+		r.AddressLine1, r.AddressLine2, r.AddressLine3, r.AddressLine4,
+		r.AddressLine5, r.AddressLine6, r.AddressLine7, r.AddressLine8,
+	}
+	for _, line := range lines {
+		if line != "" && !r.isLastLine(line) {
+			a.AddressLines = append(a.AddressLines, line)
+		}
+	}
+	return a
+}
+
+// isLastLine returns whether `line` is the record's last line (eg. "City ST 12345"),
+// which Melissa Data includes within the address lines.
+func (r Record) isLastLine(line string) bool {
+	return r.Locality != "" && strings.Contains(line, r.Locality) &&
+		(r.PostalCode == "" || strings.Contains(line, r.PostalCode))
+}
+
+// ToLocalizedPostalAddress converts the record to a PostalAddress whose address lines,
+// locality and administrative area are in the given `script` where available, eg. to
+// print a shipping label in the destination country's script.
+func (r Record) ToLocalizedPostalAddress(script Script) PostalAddress {
+	a := r.ToPostalAddress()
+	a.Locality, a.AdministrativeArea = r.LocalizedLocality(script)
+	if script == ScriptDefault {
+		return a
+	}
+	var lines []string
+	for _, line := range r.AddressLines(script) {
+		if !strings.Contains(line, a.Locality) {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > 0 {
+		a.AddressLines = lines
+	}
+	return a
+}
@@ -0,0 +1,108 @@
+package melissa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrPremiumRejected may be returned by a PremiumPolicy to reject the premium options of a call.
+var ErrPremiumRejected = errors.New("premium options rejected")
+
+// PremiumOptions are the output options billed at premium rates.
+type PremiumOptions struct {
+	// Geocode returns the latitude and longitude of addresses.
+	Geocode bool
+	// Columns are additional output columns, or column groups (eg. "GrpAddressDetails").
+	Columns []string
+}
+
+// columns returns the output columns requested by the options, formatted as Melissa Data expects.
+func (p PremiumOptions) columns() string {
+	cols := p.Columns
+	if p.Geocode {
+		cols = append([]string{"GrpGeocode"}, cols...)
+	}
+	return strings.Join(cols, ",")
+}
+
+// PremiumPolicy returns the premium options allowed for a call made using `ctx` which
+// requested `p`, typically based on its Caller. Returning an error (eg. ErrPremiumRejected)
+// fails the call without making a request, while returning fewer options downgrades it.
+type PremiumPolicy func(ctx context.Context, p PremiumOptions) (PremiumOptions, error)
+
+// WithPremium requests the premium options `p` for every call, unless overridden per call.
+func WithPremium(p PremiumOptions) Option {
+	return func(c *Client) {
+		c.premium = p
+	}
+}
+
+// WithPremiumPolicy checks the premium options of every call using `policy`.
+func WithPremiumPolicy(policy PremiumPolicy) Option {
+	return func(c *Client) {
+		c.premiumPolicy = policy
+	}
+}
+
+// WithCallPremium requests the premium options `p` for the call, overriding WithPremium.
+func WithCallPremium(p PremiumOptions) CallOption {
+	return func(cfg *callConfig) {
+		cfg.premium = &p
+	}
+}
+
+type callerKey struct{}
+
+// ContextWithCaller returns a copy of `ctx` identifying the caller of requests made with
+// it as `caller` (eg. the name of a service), for use by a PremiumPolicy.
+func ContextWithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// Caller returns the caller within `ctx`, or an empty string when there isn't one.
+func Caller(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// columns returns the output columns of a call made using `ctx`, as allowed by the policy.
+func (c Client) columns(ctx context.Context) (string, error) {
+	p := c.premium
+	if cfg, ok := ctx.Value(callKey{}).(callConfig); ok && cfg.premium != nil {
+		p = *cfg.premium
+	}
+	if c.premiumPolicy != nil {
+		var err error
+		if p, err = c.premiumPolicy(ctx, p); err != nil {
+			return "", err
+		}
+	}
+	return p.columns(), nil
+}
+
+// newQuery returns a new GlobalAddress request for the single address of the query params `qs`,
+// which are left unmodified.
+func (c Client) newQuery(ctx context.Context, qs url.Values) (*http.Request, error) {
+	cols, err := c.columns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qs = cloneValues(qs)
+	if cols != "" {
+		qs.Set("cols", cols)
+	}
+	return c.newGet(ctx, c.urlStr, qs, c.format)
+}
+
+// newBatch returns a new GlobalAddress request for the batch `body`.
+func (c Client) newBatch(ctx context.Context, body *batchRequest) (*http.Request, error) {
+	cols, err := c.columns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body.Columns = cols
+	return c.newPost(ctx, c.urlStr, body, c.format)
+}
@@ -0,0 +1,43 @@
+package melissa
+
+import "context"
+
+// Processor post-processes each verified result (eg. canonical casing, or appending
+// internal region codes) before it's returned.
+type Processor interface {
+	Process(ctx context.Context, r *Result) error
+}
+
+// ProcessorFunc adapts a function to a Processor.
+type ProcessorFunc func(ctx context.Context, r *Result) error
+
+// Process calls f(ctx, r).
+func (f ProcessorFunc) Process(ctx context.Context, r *Result) error {
+	return f(ctx, r)
+}
+
+// WithProcessors runs the processors `ps`, in order, over every result (including
+// candidates) returned by Verify and VerifyBatch. The first error fails the call.
+func WithProcessors(ps ...Processor) Option {
+	return func(c *Client) {
+		c.processors = append(c.processors, ps...)
+	}
+}
+
+// process runs the client's processors over each of the `results` and their candidates.
+func (c Client) process(ctx context.Context, results []Result) error {
+	if len(c.processors) == 0 {
+		return nil
+	}
+	for i := range results {
+		if err := c.process(ctx, results[i].Candidates); err != nil {
+			return err
+		}
+		for _, p := range c.processors {
+			if err := p.Process(ctx, &results[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
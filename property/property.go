@@ -0,0 +1,302 @@
+// Package property is a simple wrapper around Melissa Data's Property web service.
+package property
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/juztin/melissa"
+)
+
+const (
+	lookupPropertyURL = "https://property.melissadata.net/v4/WEB/LookupProperty"
+	lookupDeedsURL    = "https://property.melissadata.net/v4/WEB/LookupDeeds"
+)
+
+var (
+	// Result code mappings
+	ResultCodes = map[string]string{
+		"YS01": "FIPS/APN Match Found",
+		"YS02": "AddressKey Match Found",
+		"YS03": "Address Match Found",
+		"YS04": "Deed Records Found",
+
+		"YE01": "No FIPS/APN or AddressKey Provided",
+		"YE02": "No Match Found",
+		"YE03": "Invalid FIPS/APN or AddressKey",
+	}
+)
+
+// Client used to communicate with Melissa Data's Property web service.
+type Client struct {
+	client      melissa.Client
+	propertyURL string
+	deedsURL    string
+}
+
+// Request identifies a property, either by FIPS and APN, by Melissa address key,
+// or by address.
+type Request struct {
+	FIPS       string
+	APN        string
+	AddressKey string
+	// Columns is a comma separated list of column groups to return (eg. "GrpAll").
+	Columns string
+
+	AddressLine1 string
+	AddressLine2 string
+	City         string
+	State        string
+	PostalCode   string
+}
+
+// values returns the query params for the request, excluding empty values.
+func (r Request) values() url.Values {
+	qs := url.Values{}
+	for k, v := range map[string]string{
+		"fips":   r.FIPS,
+		"apn":    r.APN,
+		"mak":    r.AddressKey,
+		"cols":   r.Columns,
+		"a1":     r.AddressLine1,
+		"a2":     r.AddressLine2,
+		"city":   r.City,
+		"state":  r.State,
+		"postal": r.PostalCode,
+	} {
+		if v != "" {
+			qs.Set(k, v)
+		}
+	}
+	return qs
+}
+
+// Melissa Data LookupProperty response type mapping
+type Response struct {
+	Records               []Record
+	TotalRecords          string
+	TransmissionReference string
+	TransmissionResults   string
+	Version               string
+}
+
+// Melissa Data LookupProperty record type mapping
+type Record struct {
+	Results         string
+	Parcel          Parcel
+	Legal           Legal
+	PropertyAddress Address
+	OwnerAddress    Address
+	PrimaryOwner    Owner
+	SecondaryOwner  Owner
+	CurrentDeed     CurrentDeed
+	Tax             Tax
+	PropertyUseInfo PropertyUse
+	SaleInfo        Sale
+	PropertySize    Size
+	EstimatedValue  EstimatedValue
+}
+
+// Parcel identifies a property within a county.
+type Parcel struct {
+	FIPSCode         string
+	FIPSSubCode      string
+	UnformattedAPN   string
+	APNSequenceNbr   string
+	FormattedAPN     string
+	OriginalAPN      string
+	CensusTract      string
+	Zoning           string
+	RangeTownship    string
+	County           string
+	CountyFIPS       string
+	StateFIPS        string
+	MunicipalityName string
+}
+
+// Legal is the legal description of a property.
+type Legal struct {
+	LegalDescription string
+	Subdivision      string
+	Block1           string
+	LotNumber1       string
+}
+
+// Address is a property or owner mailing address.
+type Address struct {
+	Address      string
+	City         string
+	State        string
+	Zip          string
+	AddressKey   string
+	MAK          string
+	BaseMAK      string
+	CarrierRoute string
+	Latitude     string
+	Longitude    string
+}
+
+// Owner is a property owner.
+type Owner struct {
+	Name1Full   string
+	Name1First  string
+	Name1Middle string
+	Name1Last   string
+	Name1Suffix string
+	TrustFlag   string
+	CompanyFlag string
+	Name2Full   string
+	Type        string
+}
+
+// CurrentDeed is the most recent mortgage information for a property.
+type CurrentDeed struct {
+	MortgageAmount       string
+	MortgageDate         string
+	MortgageLoanTypeCode string
+	MortgageTerm         string
+	MortgageDueDate      string
+	LenderCode           string
+	LenderName           string
+}
+
+// Tax is the assessment and tax information for a property.
+type Tax struct {
+	YearAssessed              string
+	AssessedValueTotal        string
+	AssessedValueImprovements string
+	AssessedValueLand         string
+	MarketValueYear           string
+	MarketValueTotal          string
+	MarketValueImprovements   string
+	MarketValueLand           string
+	TaxFiscalYear             string
+	TaxRateArea               string
+	TaxBilledAmount           string
+	TaxDelinquentYear         string
+}
+
+// PropertyUse describes how a property is used.
+type PropertyUse struct {
+	YearBuilt               string
+	YearBuiltEffective      string
+	ZonedCodeLocal          string
+	PropertyUseMuni         string
+	PropertyUseGroup        string
+	PropertyUseStandardized string
+}
+
+// Sale is the last recorded sale of a property.
+type Sale struct {
+	AssessorLastSaleDate      string
+	AssessorLastSaleAmount    string
+	DeedLastSaleDocumentBook  string
+	DeedLastSaleDocumentPage  string
+	DeedLastDocumentNumber    string
+	DeedLastSaleDate          string
+	DeedLastSalePrice         string
+	DeedLastSaleTransactionID string
+}
+
+// Size describes the area of a property.
+type Size struct {
+	AreaBuilding               string
+	AreaBuildingDefinitionCode string
+	AreaGross                  string
+	Area1stFloor               string
+	Area2ndFloor               string
+	AreaLotAcres               string
+	AreaLotSF                  string
+	LotDepth                   string
+	LotWidth                   string
+}
+
+// EstimatedValue is Melissa Data's automated valuation of a property.
+type EstimatedValue struct {
+	EstimatedValue    string
+	EstimatedMinValue string
+	EstimatedMaxValue string
+	ConfidenceScore   string
+	ValuationDate     string
+}
+
+// Melissa Data LookupDeeds response type mapping
+type DeedResponse struct {
+	Records               []Deed
+	TotalRecords          string
+	TransmissionReference string
+	TransmissionResults   string
+	Version               string
+}
+
+// Melissa Data LookupDeeds record type mapping
+type Deed struct {
+	Results        string
+	DocInfo        DocInfo
+	TxDefInfo      TxDefInfo
+	TxAmtInfo      TxAmtInfo
+	PrimaryGrantor Owner
+	PrimaryGrantee Owner
+	Mortgage1      Mortgage
+}
+
+// DocInfo identifies a recorded deed document.
+type DocInfo struct {
+	TypeCode       string
+	Number         string
+	Book           string
+	Page           string
+	RecordingDate  string
+	InstrumentDate string
+}
+
+// TxDefInfo describes the type of a deed transaction.
+type TxDefInfo struct {
+	TransactionType              string
+	ForeclosureAuctionSale       string
+	TransferInfoPurchaseTypeCode string
+	ArmsLengthFlag               string
+}
+
+// TxAmtInfo is the monetary amount of a deed transaction.
+type TxAmtInfo struct {
+	TransferAmount             string
+	TransferAmountInfoAccuracy string
+	TransferTaxTotal           string
+}
+
+// Mortgage is a mortgage recorded alongside a deed.
+type Mortgage struct {
+	RecordingDate string
+	Type          string
+	Amount        string
+	LenderCode    string
+	LenderName    string
+	TermType      string
+	Term          string
+	InterestRate  string
+}
+
+// LookupProperty returns the property details for the property identified by `r`.
+func (c Client) LookupProperty(ctx context.Context, r Request) (Response, error) {
+	var resp Response
+	err := c.client.Get(ctx, c.propertyURL, r.values(), &resp)
+	return resp, err
+}
+
+// LookupDeed returns the deed history for the property identified by the given `fips` and `apn`.
+func (c Client) LookupDeed(ctx context.Context, fips, apn string) (DeedResponse, error) {
+	var resp DeedResponse
+	qs := Request{FIPS: fips, APN: apn}.values()
+	err := c.client.Get(ctx, c.deedsURL, qs, &resp)
+	return resp, err
+}
+
+// NewClient returns a new Property client which uses `c` for communication.
+func NewClient(c melissa.Client) Client {
+	return Client{
+		client:      c,
+		propertyURL: c.URL(melissa.ServiceProperty, lookupPropertyURL),
+		deedsURL:    c.URL(melissa.ServicePropertyDeeds, lookupDeedsURL),
+	}
+}
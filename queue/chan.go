@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// ChanQueue is an in-process Queue backed by a buffered channel.
+// Messages are removed when dequeued, so Ack is a no-op, while Nack requeues the
+// message to be dequeued before any others.
+type ChanQueue struct {
+	ch   chan Message
+	once sync.Once
+	done chan struct{}
+
+	mu sync.Mutex
+	// requeued are the negatively acknowledged messages, unbounded so Nack never blocks.
+	requeued []Message
+	// wake signals a blocked Dequeue that a message was requeued.
+	wake chan struct{}
+}
+
+// Enqueue implements Queue, blocking while the queue is full.
+func (q *ChanQueue) Enqueue(ctx context.Context, m Message) error {
+	select {
+	case <-q.done:
+		return ErrClosed
+	default:
+	}
+	select {
+	case q.ch <- m:
+		return nil
+	case <-q.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dequeue implements Queue, returning requeued messages first.
+func (q *ChanQueue) Dequeue(ctx context.Context) (Message, error) {
+	for {
+		if m, ok := q.popRequeued(); ok {
+			return m, nil
+		}
+		select {
+		case m := <-q.ch:
+			return m, nil
+		case <-q.wake:
+		case <-q.done:
+			// Drain the messages queued before the queue was closed.
+			if m, ok := q.popRequeued(); ok {
+				return m, nil
+			}
+			select {
+			case m := <-q.ch:
+				return m, nil
+			default:
+				return Message{}, ErrClosed
+			}
+		case <-ctx.Done():
+			return Message{}, ctx.Err()
+		}
+	}
+}
+
+// popRequeued removes and returns the first requeued message, if any.
+func (q *ChanQueue) popRequeued() (Message, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.requeued) == 0 {
+		return Message{}, false
+	}
+	m := q.requeued[0]
+	q.requeued = q.requeued[1:]
+	return m, true
+}
+
+// Ack implements Queue.
+func (q *ChanQueue) Ack(ctx context.Context, m Message) error {
+	return nil
+}
+
+// Nack implements Queue, requeuing `m` without blocking, even once the queue is closed.
+func (q *ChanQueue) Nack(ctx context.Context, m Message) error {
+	q.mu.Lock()
+	q.requeued = append(q.requeued, m)
+	q.mu.Unlock()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close closes the queue, failing any blocked and subsequent Enqueue calls with
+// ErrClosed. Queued messages are still dequeued, after which Dequeue returns ErrClosed.
+func (q *ChanQueue) Close() {
+	q.once.Do(func() {
+		close(q.done)
+	})
+}
+
+// NewChanQueue returns a ChanQueue holding up to `size` messages.
+func NewChanQueue(size int) *ChanQueue {
+	return &ChanQueue{ch: make(chan Message, size), done: make(chan struct{}), wake: make(chan struct{}, 1)}
+}
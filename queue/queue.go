@@ -0,0 +1,231 @@
+// Package queue decouples address verification from the request path, using a Producer
+// to enqueue addresses and a Consumer to drain them through a bulk batch.Verifier.
+//
+// Queue is implemented in-process by ChanQueue, and may be implemented by adapters
+// for external brokers (eg. SQS or Kafka).
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/juztin/melissa"
+	"github.com/juztin/melissa/batch"
+)
+
+// ErrClosed is returned by a Queue once it's closed and drained.
+var ErrClosed = errors.New("queue closed")
+
+// Message is a queued verification request.
+type Message struct {
+	ID      string
+	Request melissa.AddressRequest
+	// Receipt is an opaque handle, set by Dequeue, identifying the delivery of the
+	// message to Ack or Nack (eg. an SQS receipt handle or a Kafka offset).
+	Receipt string
+}
+
+// Queue holds messages awaiting verification.
+//
+// Messages are delivered at least once: a dequeued message is only removed once it's
+// acknowledged, and is redelivered when negatively acknowledged.
+type Queue interface {
+	Enqueue(ctx context.Context, m Message) error
+	// Dequeue blocks until a message is available, returning ErrClosed once the
+	// queue is closed and drained.
+	Dequeue(ctx context.Context) (Message, error)
+	// Ack acknowledges the dequeued message `m` was processed, removing it from the queue.
+	Ack(ctx context.Context, m Message) error
+	// Nack returns the dequeued message `m`, which failed to process, to the queue.
+	// It mustn't block waiting for the message to be dequeued.
+	Nack(ctx context.Context, m Message) error
+}
+
+// Producer enqueues addresses for verification.
+type Producer struct {
+	q Queue
+}
+
+// Enqueue queues the address `r`, returning the ID of its message. The ID is the
+// RecordID of `r`, or a random ID when empty.
+func (p Producer) Enqueue(ctx context.Context, r melissa.AddressRequest) (string, error) {
+	id := r.RecordID
+	if id == "" {
+		var b [16]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return "", err
+		}
+		id = hex.EncodeToString(b[:])
+	}
+	return id, p.q.Enqueue(ctx, Message{ID: id, Request: r})
+}
+
+// NewProducer returns a Producer enqueuing to `q`.
+func NewProducer(q Queue) Producer {
+	return Producer{q}
+}
+
+// Handler receives the result of each verified message, which is acknowledged once
+// the handler returns without error.
+type Handler func(ctx context.Context, m Message, res melissa.Result) error
+
+// Consumer drains a Queue through a batch.Verifier.
+type Consumer struct {
+	q         Queue
+	v         *batch.Verifier
+	h         Handler
+	chunkSize int
+	linger    time.Duration
+}
+
+// Run verifies messages from the queue until it's closed, or `ctx` is done. Messages are
+// gathered into batches of up to the chunk size, waiting at most the linger duration
+// for a batch to fill once its first message arrives. The run stops at the first error.
+//
+// Each message is acknowledged once handled, or dead-lettered by the batch.Verifier.
+// Messages which weren't are negatively acknowledged, so they're redelivered.
+func (c *Consumer) Run(ctx context.Context) error {
+	for {
+		msgs, err := c.gather(ctx)
+		if len(msgs) > 0 {
+			if verr := c.process(ctx, msgs); verr != nil {
+				return verr
+			}
+		}
+		if err == ErrClosed {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// process verifies the batch of messages `msgs`, acknowledging each once handled.
+func (c *Consumer) process(ctx context.Context, msgs []Message) error {
+	rows := make([]batch.Row, len(msgs))
+	for i, m := range msgs {
+		rows[i] = toRow(i, m)
+	}
+	sink := &handlerSink{ctx: ctx, q: c.q, h: c.h, msgs: msgs, acked: make([]bool, len(msgs))}
+	sum, err := c.v.Run(ctx, &rowSource{rows}, sink)
+	for _, f := range sum.Errors.Failures {
+		if i := f.Row.Line - 1; !sink.acked[i] {
+			sink.acked[i] = true
+			if aerr := c.q.Ack(ctx, msgs[i]); err == nil {
+				err = aerr
+			}
+		}
+	}
+
+	for i, m := range msgs {
+		if sink.acked[i] {
+			continue
+		}
+		if nerr := c.q.Nack(ctx, m); err == nil {
+			err = nerr
+		}
+	}
+	return err
+}
+
+// gather dequeues the next batch of messages.
+func (c *Consumer) gather(ctx context.Context) ([]Message, error) {
+	m, err := c.q.Dequeue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msgs := []Message{m}
+
+	lctx, cancel := context.WithTimeout(ctx, c.linger)
+	defer cancel()
+	for len(msgs) < c.chunkSize {
+		m, err := c.q.Dequeue(lctx)
+		if err != nil {
+			if lctx.Err() != nil && ctx.Err() == nil {
+				err = nil
+			}
+			return msgs, err
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
+
+// handlerSink is a batch.Sink passing each result to a Handler, acknowledging the
+// message once handled.
+type handlerSink struct {
+	ctx   context.Context
+	q     Queue
+	h     Handler
+	msgs  []Message
+	acked []bool
+}
+
+func (s *handlerSink) Write(row batch.Row, res melissa.Result) error {
+	i := row.Line - 1
+	m := s.msgs[i]
+	if err := s.h(s.ctx, Message{m.ID, row.Request, m.Receipt}, res); err != nil {
+		return err
+	}
+	s.acked[i] = true
+	return s.q.Ack(s.ctx, m)
+}
+
+// toRow returns the message `m`, at index `i` of its batch, as a row. The row's Line
+// is its one-based position within the batch, identifying it regardless of its ID.
+func toRow(i int, m Message) batch.Row {
+	r := batch.Row{Request: m.Request, Line: i + 1}
+	r.Request.RecordID = m.ID
+	return r
+}
+
+// rowSource is a batch.Source of gathered rows.
+type rowSource struct {
+	rows []batch.Row
+}
+
+func (s *rowSource) Next() (batch.Row, error) {
+	if len(s.rows) == 0 {
+		return batch.Row{}, io.EOF
+	}
+	r := s.rows[0]
+	s.rows = s.rows[1:]
+	return r, nil
+}
+
+// Option configures a Consumer.
+type Option func(*Consumer)
+
+// WithBatchSize gathers at most `n` messages per batch, defaulting to melissa.MaxRecords.
+func WithBatchSize(n int) Option {
+	return func(c *Consumer) {
+		c.chunkSize = n
+	}
+}
+
+// WithLinger waits at most `d` for a batch to fill, defaulting to 100ms.
+func WithLinger(d time.Duration) Option {
+	return func(c *Consumer) {
+		c.linger = d
+	}
+}
+
+// NewConsumer returns a Consumer verifying messages from `q` using `v`, passing
+// each result to `h`.
+func NewConsumer(q Queue, v *batch.Verifier, h Handler, opts ...Option) *Consumer {
+	c := &Consumer{
+		q:         q,
+		v:         v,
+		h:         h,
+		chunkSize: melissa.MaxRecords,
+		linger:    100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
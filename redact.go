@@ -0,0 +1,101 @@
+package melissa
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// redacted replaces secrets within logged output.
+const redacted = "REDACTED"
+
+// secretParams are the query params holding the built-in credentials.
+var secretParams = []string{"id", "license"}
+
+// RedactURL returns `u` as a string with the query params of the built-in credentials
+// masked, safe to log. Use Client.RedactURL when authenticating using a custom param.
+func RedactURL(u *url.URL) string {
+	return redactURL(u, secretParams)
+}
+
+// RedactURL returns `u` as a string with the query params holding the client's
+// credential masked, safe to log.
+func (c Client) RedactURL(u *url.URL) string {
+	return redactURL(u, c.secretParams())
+}
+
+// secretParams returns the query params holding the client's credential, determined by
+// authenticating an empty request, along with those of the built-in credentials.
+func (c Client) secretParams() []string {
+	qs := url.Values{}
+	for _, p := range secretParams {
+		qs.Set(p, "")
+	}
+	if c.cred != nil {
+		c.cred.Authenticate(qs, http.Header{})
+	}
+	params := make([]string, 0, len(qs))
+	for p := range qs {
+		params = append(params, p)
+	}
+	sort.Strings(params)
+	return params
+}
+
+// redactURL returns `u` as a string with the query `params` masked.
+func redactURL(u *url.URL, params []string) string {
+	if u == nil {
+		return ""
+	}
+	qs := u.Query()
+	found := false
+	for _, p := range params {
+		if qs.Has(p) {
+			qs.Set(p, redacted)
+			found = true
+		}
+	}
+	if !found {
+		return u.String()
+	}
+	r := *u
+	r.RawQuery = qs.Encode()
+	return r.String()
+}
+
+// redactError masks the query `params` within the URL of a *url.Error returned by the http client.
+func redactError(err error, params []string) error {
+	if ue, ok := err.(*url.Error); ok {
+		if u, perr := url.Parse(ue.URL); perr == nil {
+			return &url.Error{Op: ue.Op, URL: redactURL(u, params), Err: ue.Err}
+		}
+	}
+	return err
+}
+
+// String returns a description of the round-trip with secrets masked, safe to log.
+func (rt RoundTrip) String() string {
+	if rt.Request == nil {
+		return "<nil>"
+	}
+	status := "-"
+	if rt.Response != nil {
+		status = rt.Response.Status
+	}
+	params := rt.secrets
+	if params == nil {
+		params = secretParams
+	}
+	return fmt.Sprintf("%s %s %s %s", rt.Request.Method, redactURL(rt.Request.URL, params), status, rt.Duration)
+}
+
+// String returns a description of the client with its key masked, safe to log.
+func (c Client) String() string {
+	return fmt.Sprintf("melissa.Client{url: %s, key: %s}", c.urlStr, redacted)
+}
+
+// GoString implements fmt.GoStringer, masking the key.
+func (c Client) GoString() string {
+	return c.String()
+}
@@ -0,0 +1,57 @@
+package melissa
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+type referenceKey struct{}
+
+// ContextWithTransmissionReference returns a copy of `ctx` which sends `ref` as the
+// TransmissionReference of requests made with it, to correlate them with Melissa Data's logs.
+func ContextWithTransmissionReference(ctx context.Context, ref string) context.Context {
+	return context.WithValue(ctx, referenceKey{}, ref)
+}
+
+// TransmissionReference returns the TransmissionReference within `ctx`,
+// or an empty string when there isn't one.
+func TransmissionReference(ctx context.Context) string {
+	ref, _ := ctx.Value(referenceKey{}).(string)
+	return ref
+}
+
+// ensureReference returns `ctx` and its TransmissionReference, generating a new
+// random UUID reference when `ctx` doesn't have one.
+func ensureReference(ctx context.Context) (context.Context, string) {
+	if ref := TransmissionReference(ctx); ref != "" {
+		return ctx, ref
+	}
+	ref := newUUID()
+	return ContextWithTransmissionReference(ctx, ref), ref
+}
+
+// newUUID returns a new random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Transmission is the common header of batch request payloads.
+// Embed it within a payload given to Client.Post to have the client populate it.
+type Transmission struct {
+	CustomerID            string
+	TransmissionReference string
+}
+
+func (t *Transmission) transmission() *Transmission {
+	return t
+}
+
+// transmitter is implemented by payloads embedding a Transmission.
+type transmitter interface {
+	transmission() *Transmission
+}
@@ -0,0 +1,109 @@
+package melissa
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+
+	"github.com/juztin/melissa/countries"
+)
+
+// MaxRecords is the maximum number of records allowed in a single batch request.
+const MaxRecords = 100
+
+// AddressRequest is a single address to be verified.
+type AddressRequest struct {
+	RecordID                string
+	Organization            string
+	AddressLine1            string
+	AddressLine2            string
+	AddressLine3            string
+	AddressLine4            string
+	AddressLine5            string
+	AddressLine6            string
+	AddressLine7            string
+	AddressLine8            string
+	DoubleDependentLocality string
+	DependentLocality       string
+	Locality                string
+	SubAdministrativeArea   string
+	AdministrativeArea      string
+	PostalCode              string
+	SubNationalArea         string
+	Country                 string
+
+	// OutputScript is the script the address is returned in.
+	OutputScript Script `json:"-" xml:"-"`
+	// Language is the preferred language of the returned locality and administrative area.
+	Language Language `json:"-" xml:"-"`
+	// MaxSuggestions is the maximum number of candidate records returned when the address
+	// matches multiple addresses (AE05), ignored when zero.
+	MaxSuggestions int `json:"-" xml:"-"`
+}
+
+// Values returns the query params for the address, excluding empty values.
+// The Country is normalized to its ISO 3166-1 alpha-2 code when recognized.
+func (r AddressRequest) Values() url.Values {
+	qs := url.Values{}
+	for k, v := range map[string]string{
+		"org":        r.Organization,
+		"a1":         r.AddressLine1,
+		"a2":         r.AddressLine2,
+		"a3":         r.AddressLine3,
+		"a4":         r.AddressLine4,
+		"a5":         r.AddressLine5,
+		"a6":         r.AddressLine6,
+		"a7":         r.AddressLine7,
+		"a8":         r.AddressLine8,
+		"ddeploc":    r.DoubleDependentLocality,
+		"deploc":     r.DependentLocality,
+		"loc":        r.Locality,
+		"subadmarea": r.SubAdministrativeArea,
+		"admarea":    r.AdministrativeArea,
+		"postal":     r.PostalCode,
+		"subnatarea": r.SubNationalArea,
+		"ctry":       countries.Alpha2(r.Country),
+	} {
+		if v != "" {
+			qs.Set(k, v)
+		}
+	}
+	if opt := r.options(); len(opt) > 0 {
+		qs.Set("opt", opt.String())
+	}
+	return qs
+}
+
+// batchRequest is the JSON payload used for batch POST requests.
+type batchRequest struct {
+	XMLName xml.Name `json:"-" xml:"Request"`
+	Transmission
+	Options string           `json:",omitempty" xml:",omitempty"`
+	Columns string           `json:",omitempty" xml:",omitempty"`
+	Records []AddressRequest `xml:"Records>RequestRecord"`
+}
+
+// newBatchRequest returns the payload for the given `records`. Options apply to the whole
+// transmission, so those of the first record are used.
+func newBatchRequest(records []AddressRequest) *batchRequest {
+	b := &batchRequest{Records: prepareRecords(records)}
+	if len(records) > 0 {
+		b.Options = records[0].options().String()
+	}
+	return b
+}
+
+// prepareRecords returns a copy of `records` ready to be sent. Every empty RecordID is set
+// to the record's one-based position within the batch and countries are normalized to their
+// ISO 3166-1 alpha-2 code.
+func prepareRecords(records []AddressRequest) []AddressRequest {
+	out := make([]AddressRequest, len(records))
+	for i, r := range records {
+		if r.RecordID == "" {
+			r.RecordID = strconv.Itoa(i + 1)
+		}
+		r.Country = countries.Alpha2(r.Country)
+		out[i] = r
+	}
+	return out
+}
@@ -0,0 +1,163 @@
+package melissa
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Outcome is the overall verification outcome of a record.
+type Outcome int
+
+const (
+	// Failed records could not be verified.
+	Failed Outcome = iota
+	// PartiallyVerified records were verified only to a partial level (AV1x).
+	PartiallyVerified
+	// Corrected records were verified after Melissa Data changed one or more components.
+	Corrected
+	// Verified records were verified as given.
+	Verified
+)
+
+var outcomeNames = [...]string{
+	Failed:            "Failed",
+	PartiallyVerified: "PartiallyVerified",
+	Corrected:         "Corrected",
+	Verified:          "Verified",
+}
+
+func (o Outcome) String() string {
+	if o < 0 || int(o) >= len(outcomeNames) {
+		return "Outcome(" + strconv.Itoa(int(o)) + ")"
+	}
+	return outcomeNames[o]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// Result is the verification result of a single address.
+type Result struct {
+	Record
+	Outcome Outcome
+	// Score is the 0-100 deliverability confidence score of the record.
+	Score int
+	// Corrections are the change (AC) codes applied to the record.
+	Corrections []string
+	// Errors are the error (AE) codes reported for the record.
+	Errors []string
+	// Candidates are the suggested records returned for an ambiguous address (AE05),
+	// when requested using AddressRequest.MaxSuggestions.
+	Candidates []Result `json:",omitempty"`
+}
+
+// Ambiguous returns whether the address matched multiple addresses (AE05).
+func (r Result) Ambiguous() bool {
+	for _, code := range r.Errors {
+		if code == "AE05" {
+			return true
+		}
+	}
+	return false
+}
+
+// newResults classifies the given `records`, grouping consecutive records sharing a
+// RecordID as candidates of the first.
+func newResults(records []Record) []Result {
+	var results []Result
+	for i, rec := range records {
+		if i > 0 && rec.RecordID == records[i-1].RecordID {
+			last := &results[len(results)-1]
+			last.Candidates = append(last.Candidates, NewResult(rec))
+			continue
+		}
+		results = append(results, NewResult(rec))
+	}
+	return results
+}
+
+// NewResult classifies the given `rec` by its result codes.
+func NewResult(rec Record) Result {
+	r := Result{Record: rec, Score: Score(rec)}
+	var partial, full bool
+	for _, code := range splitCodes(rec.Results) {
+		switch c := Classify(code); {
+		case c.Category == CategoryChange:
+			r.Corrections = append(r.Corrections, code)
+		case c.Category == CategoryError:
+			r.Errors = append(r.Errors, code)
+		case c.Category == CategoryStatus && c.Severity == SeverityWarning:
+			partial = true
+		case c.Category == CategoryStatus:
+			full = true
+		}
+	}
+
+	switch {
+	case full && len(r.Corrections) > 0:
+		r.Outcome = Corrected
+	case full:
+		r.Outcome = Verified
+	case partial:
+		r.Outcome = PartiallyVerified
+	default:
+		r.Outcome = Failed
+	}
+	return r
+}
+
+// Codes returns the individual result codes of the record.
+func (r Record) Codes() []string {
+	return splitCodes(r.Results)
+}
+
+// VerificationLevel returns the verification (AV) code of the record,
+// or an empty string when it wasn't verified.
+func (r Record) VerificationLevel() string {
+	for _, code := range r.Codes() {
+		if strings.HasPrefix(code, "AV") {
+			return code
+		}
+	}
+	return ""
+}
+
+// AddressLines returns the lines of the formatted address in the given `script`, when the
+// service returned the address in that script, otherwise those of FormattedAddress.
+func (r Record) AddressLines(script Script) []string {
+	formatted := r.FormattedAddress
+	switch {
+	case script == ScriptLatin && r.FormattedAddressLatin != "":
+		formatted = r.FormattedAddressLatin
+	case script == ScriptNative && r.FormattedAddressNative != "":
+		formatted = r.FormattedAddressNative
+	}
+	var lines []string
+	for _, line := range strings.Split(formatted, ";") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// LocalizedLocality returns the locality and administrative area in the given `script`,
+// when the service returned them in that script, otherwise Locality and AdministrativeArea.
+func (r Record) LocalizedLocality(script Script) (locality, adminArea string) {
+	locality, adminArea = r.Locality, r.AdministrativeArea
+	switch {
+	case script == ScriptLatin && r.LocalityLatin != "":
+		locality = r.LocalityLatin
+	case script == ScriptNative && r.LocalityNative != "":
+		locality = r.LocalityNative
+	}
+	switch {
+	case script == ScriptLatin && r.AdministrativeAreaLatin != "":
+		adminArea = r.AdministrativeAreaLatin
+	case script == ScriptNative && r.AdministrativeAreaNative != "":
+		adminArea = r.AdministrativeAreaNative
+	}
+	return locality, adminArea
+}
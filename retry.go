@@ -0,0 +1,70 @@
+package melissa
+
+import (
+	"errors"
+	"strings"
+)
+
+// Attempt describes a failed request attempt, for a RetryPolicy to decide whether to retry it.
+type Attempt struct {
+	// N is the zero-based number of the attempt.
+	N int
+	// StatusCode is the HTTP status of the response, or zero when there wasn't one.
+	StatusCode int
+	// Codes are the transmission codes (eg. "SE01") of the response.
+	Codes []string
+	// Err is the error of the attempt, or nil when it failed only due to its Codes.
+	Err error
+}
+
+// RetryPolicy decides whether a failed attempt is retried, within the number of
+// retries configured by WithRetry.
+type RetryPolicy interface {
+	Retry(a Attempt) bool
+}
+
+// RetryPolicyFunc adapts a function to a RetryPolicy.
+type RetryPolicyFunc func(a Attempt) bool
+
+// Retry calls f(a).
+func (f RetryPolicyFunc) Retry(a Attempt) bool {
+	return f(a)
+}
+
+// DefaultRetryPolicy retries server errors (SE01), network errors and 5xx responses,
+// but never requests Melissa Data rejected (GE codes, eg. GE04 and GE05 for a bad
+// CustomerID), which will fail however often they're sent.
+var DefaultRetryPolicy RetryPolicy = RetryPolicyFunc(defaultRetry)
+
+func defaultRetry(a Attempt) bool {
+	transient := false
+	for _, code := range a.Codes {
+		if strings.HasPrefix(code, "GE") {
+			return false
+		}
+		transient = transient || strings.HasPrefix(code, "SE")
+	}
+	return transient || (a.Err != nil && retryable(a.Err))
+}
+
+// WithRetryPolicy decides which failed attempts are retried using `p`, instead of DefaultRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = p
+	}
+}
+
+// newAttempt returns the attempt numbered `n` which unmarshalled its response into `v`,
+// failing with `err`. Transmission codes are only known for GlobalAddress responses which
+// weren't streamed, as streamed records can't be taken back.
+func newAttempt(n int, v interface{}, err error) Attempt {
+	a := Attempt{N: n, Err: err}
+	var se StatusError
+	if errors.As(err, &se) {
+		a.StatusCode = se.StatusCode
+	} else if r, ok := v.(*Response); ok && err == nil && r.each == nil {
+		a.StatusCode = 200
+		a.Codes = splitCodes(r.TransmissionResults)
+	}
+	return a
+}
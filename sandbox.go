@@ -0,0 +1,175 @@
+package melissa
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Magic inputs recognized by the sandbox.
+const (
+	// SandboxInvalidPostal as the PostalCode returns AE01 (postal code error).
+	SandboxInvalidPostal = "00000"
+	// SandboxVerified as AddressLine1 returns a verified address with a rooftop geocode.
+	SandboxVerified = "123 Test St"
+	// SandboxMultipleMatches as AddressLine1 returns AE05 (multiple matches).
+	SandboxMultipleMatches = "100 Main St"
+)
+
+// WithSandbox short-circuits all requests, including those of service clients built from
+// the Client, responding with deterministic fixtures selected by magic inputs instead:
+//
+//	PostalCode SandboxInvalidPostal           -> AE01
+//	AddressLine1 SandboxVerified              -> AV25,GS05 with a rooftop geocode
+//	AddressLine1 SandboxMultipleMatches       -> AE05
+//	anything else                             -> AV24,GS03 with a ZIP centroid geocode
+//
+// Service subpackages receive empty responses. No credentials are required.
+func WithSandbox() Option {
+	return func(c *Client) {
+		c.sandbox = true
+	}
+}
+
+// sandboxTransport is an http.RoundTripper responding with sandbox fixtures.
+type sandboxTransport struct {
+	urlStr string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t sandboxTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u := *req.URL
+	u.RawQuery = ""
+	resp := Response{Version: "sandbox", TransmissionReference: TransmissionReference(req.Context())}
+	if u.String() == t.urlStr {
+		_, records, err := ParseRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		for i, r := range records {
+			if r.RecordID == "" && len(records) > 1 {
+				r.RecordID = strconv.Itoa(i + 1)
+			}
+			resp.Records = append(resp.Records, SandboxRecord(r))
+		}
+	}
+	resp.TotalRecords = strconv.Itoa(len(resp.Records))
+
+	contentType, body, err := MarshalResponse(req, resp)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {contentType}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// ParseRequest returns the transmission and addresses of the GlobalAddress request `req`,
+// sent as a GET request, or as a JSON, XML or SOAP batch, for use by fakes of the service.
+// The CustomerID of GET requests is taken from the "id" or "license" query param.
+func ParseRequest(req *http.Request) (Transmission, []AddressRequest, error) {
+	if req.Method == "GET" {
+		qs := req.URL.Query()
+		t := Transmission{CustomerID: qs.Get("id"), TransmissionReference: qs.Get("t")}
+		if t.CustomerID == "" {
+			t.CustomerID = qs.Get("license")
+		}
+		return t, []AddressRequest{requestFromValues(qs)}, nil
+	}
+	if req.Body == nil {
+		return Transmission{}, nil, nil
+	}
+	var body io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return Transmission{}, nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+	var b batchRequest
+	err := formatOf(req.Header.Get("Content-Type")).decode(body, &b)
+	return b.Transmission, b.Records, err
+}
+
+// MarshalResponse returns `resp` encoded in the format accepted by the GlobalAddress
+// request `req`, along with its content type, for use by fakes of the service.
+func MarshalResponse(req *http.Request, resp Response) (string, []byte, error) {
+	f := formatOf(req.Header.Get("Accept"))
+	body, err := f.marshal(resp)
+	return f.contentType(), body, err
+}
+
+// SandboxRecord returns the sandbox's fixture record for the address `r`, as described
+// by WithSandbox. The address is echoed back upper-cased, with a formatted address.
+func SandboxRecord(r AddressRequest) Record {
+	rec := Record{
+		RecordID:                r.RecordID,
+		Organization:            r.Organization,
+		AddressLine1:            strings.ToUpper(r.AddressLine1),
+		AddressLine2:            strings.ToUpper(r.AddressLine2),
+		Locality:                strings.ToUpper(r.Locality),
+		AdministrativeArea:      strings.ToUpper(r.AdministrativeArea),
+		PostalCode:              r.PostalCode,
+		CountryISO3166_1_Alpha2: strings.ToUpper(r.Country),
+	}
+	switch {
+	case r.PostalCode == SandboxInvalidPostal:
+		rec.Results = "AE01"
+	case strings.EqualFold(r.AddressLine1, SandboxVerified):
+		rec.Results = "AV25,GS05"
+		rec.Latitude, rec.Longitude = "38.897700", "-77.036500"
+	case strings.EqualFold(r.AddressLine1, SandboxMultipleMatches):
+		rec.Results = "AE05"
+	default:
+		rec.Results = "AV24,GS03"
+		rec.Latitude, rec.Longitude = "38.900000", "-77.040000"
+	}
+	var lines []string
+	for _, s := range []string{rec.AddressLine1, rec.AddressLine2, rec.Locality, rec.AdministrativeArea, rec.PostalCode} {
+		if s != "" {
+			lines = append(lines, s)
+		}
+	}
+	rec.FormattedAddress = strings.Join(lines, ";")
+	return rec
+}
+
+// requestFromValues returns the address given by the GET query params `qs`,
+// the inverse of AddressRequest.Values.
+func requestFromValues(qs url.Values) AddressRequest {
+	get := qs.Get
+	return AddressRequest{
+		Organization:            get("org"),
+		AddressLine1:            get("a1"),
+		AddressLine2:            get("a2"),
+		AddressLine3:            get("a3"),
+		AddressLine4:            get("a4"),
+		AddressLine5:            get("a5"),
+		AddressLine6:            get("a6"),
+		AddressLine7:            get("a7"),
+		AddressLine8:            get("a8"),
+		DoubleDependentLocality: get("ddeploc"),
+		DependentLocality:       get("deploc"),
+		Locality:                get("loc"),
+		SubAdministrativeArea:   get("subadmarea"),
+		AdministrativeArea:      get("admarea"),
+		PostalCode:              get("postal"),
+		SubNationalArea:         get("subnatarea"),
+		Country:                 get("ctry"),
+	}
+}
@@ -0,0 +1,88 @@
+package melissa
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Sanitizer normalizes an address before it's sent, so that predictably invalid input
+// doesn't waste lookups.
+type Sanitizer func(r *AddressRequest)
+
+// WithSanitizers applies the sanitizers `ss`, in order, to every address sent by
+// Verify, QueryBatch and QueryBatchFunc.
+func WithSanitizers(ss ...Sanitizer) Option {
+	return func(c *Client) {
+		c.sanitizers = append(c.sanitizers, ss...)
+	}
+}
+
+// DefaultSanitizers strip control characters, collapse whitespace, upper case the
+// country and move unit designators into AddressLine2.
+var DefaultSanitizers = []Sanitizer{StripControl, CollapseSpace, UpperCountry, MoveUnit}
+
+// StripControl removes control characters from every field.
+func StripControl(r *AddressRequest) {
+	for _, f := range r.fields() {
+		*f = strings.Map(func(c rune) rune {
+			if unicode.IsControl(c) && !unicode.IsSpace(c) {
+				return -1
+			}
+			return c
+		}, *f)
+	}
+}
+
+// CollapseSpace trims every field, collapsing runs of whitespace into a single space.
+func CollapseSpace(r *AddressRequest) {
+	for _, f := range r.fields() {
+		*f = strings.Join(strings.Fields(*f), " ")
+	}
+}
+
+// UpperCountry upper cases the country.
+func UpperCountry(r *AddressRequest) {
+	r.Country = strings.ToUpper(r.Country)
+}
+
+// unitRE matches a trailing unit designator (eg. "Apt 4B", "Suite 100", "#12").
+var unitRE = regexp.MustCompile(`(?i)^(.*?)[\s,]+((?:APT|APARTMENT|UNIT|STE|SUITE|FL|FLOOR|RM|ROOM)\.?\s*#?\s*[A-Z0-9-]*\d[A-Z0-9-]*|#\s*[A-Z0-9-]*\d[A-Z0-9-]*)$`)
+
+// MoveUnit moves a unit designator trailing AddressLine1 (eg. "1 Main St Apt 4B")
+// into AddressLine2, when it's empty.
+func MoveUnit(r *AddressRequest) {
+	if r.AddressLine2 != "" {
+		return
+	}
+	if m := unitRE.FindStringSubmatch(r.AddressLine1); m != nil && m[1] != "" {
+		r.AddressLine1, r.AddressLine2 = m[1], m[2]
+	}
+}
+
+// fields returns pointers to the address fields of `r`.
+func (r *AddressRequest) fields() []*string {
+	return []*string{
+		&r.Organization,
+		&r.AddressLine1, &r.AddressLine2, &r.AddressLine3, &r.AddressLine4,
+		&r.AddressLine5, &r.AddressLine6, &r.AddressLine7, &r.AddressLine8,
+		&r.DoubleDependentLocality, &r.DependentLocality, &r.Locality,
+		&r.SubAdministrativeArea, &r.AdministrativeArea, &r.PostalCode,
+		&r.SubNationalArea, &r.Country,
+	}
+}
+
+// sanitize returns a copy of `records` with the client's sanitizers applied.
+func (c Client) sanitize(records []AddressRequest) []AddressRequest {
+	if len(c.sanitizers) == 0 {
+		return records
+	}
+	out := make([]AddressRequest, len(records))
+	for i, r := range records {
+		for _, s := range c.sanitizers {
+			s(&r)
+		}
+		out[i] = r
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package melissa
+
+// Score weighting. A record starts from the score of its verification (AV) level,
+// and loses points for each correction (AC) and error (AE), and for a geocode (GS)
+// coarser than rooftop. Scores are clamped to 0-100.
+var (
+	levelScores = map[string]int{
+		"AV25": 100, "AV24": 95, "AV23": 85, "AV22": 75, "AV21": 65,
+		"AV15": 50, "AV14": 45, "AV13": 40, "AV12": 35, "AV11": 30,
+	}
+	geoPenalties = map[string]int{
+		"GS05": 0, "GS06": 0,
+		"GS01": 5, "GS02": 5, "GS03": 10,
+	}
+
+	correctionPenalty = 2
+	maxCorrections    = 10
+	errorPenalty      = 20
+	noGeocodePenalty  = 10
+)
+
+// Score converts the result codes of `rec` into a 0-100 deliverability confidence score.
+func Score(rec Record) int {
+	var score, corrections, penalty int
+	geocoded := false
+	for _, code := range rec.Codes() {
+		switch Classify(code).Category {
+		case CategoryStatus:
+			if s := levelScores[code]; s > score {
+				score = s
+			}
+		case CategoryChange:
+			corrections += correctionPenalty
+		case CategoryError:
+			penalty += errorPenalty
+		case CategoryGeocodeStatus:
+			geocoded = true
+			penalty += geoPenalties[code]
+		}
+	}
+	if corrections > maxCorrections {
+		corrections = maxCorrections
+	}
+	if !geocoded {
+		penalty += noGeocodePenalty
+	}
+
+	score -= corrections + penalty
+	if score < 0 {
+		return 0
+	}
+	return score
+}
+
+// IsShippable returns whether the result's deliverability Score is at least `minScore`.
+func (r Result) IsShippable(minScore int) bool {
+	return r.Score >= minScore
+}
@@ -0,0 +1,97 @@
+package melissa
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	globalAddressSOAPURL = "https://address.melissadata.net/v3/SOAP/GlobalAddress"
+
+	soapEnvelopeNS = "http://schemas.xmlsoap.org/soap/envelope/"
+	soapServiceNS  = "urn:mdGlobalAddress"
+	soapAction     = soapServiceNS + "/doGlobalAddress"
+)
+
+// SOAPFault is returned when the SOAP service responds with a fault.
+type SOAPFault struct {
+	Code   string `xml:"faultcode"`
+	String string `xml:"faultstring"`
+	Detail string `xml:"detail"`
+}
+
+func (f SOAPFault) Error() string {
+	return fmt.Sprintf("soap fault, %s: %s", f.Code, f.String)
+}
+
+// soapEnvelope is a SOAP 1.1 envelope invoking the doGlobalAddress operation with its body.
+type soapEnvelope struct {
+	XMLName xml.Name `xml:"soap:Envelope"`
+	NS      string   `xml:"xmlns:soap,attr"`
+	Body    struct {
+		Operation struct {
+			NS      string `xml:"xmlns,attr"`
+			Content interface{}
+		} `xml:"doGlobalAddress"`
+	} `xml:"soap:Body"`
+}
+
+// marshalSOAP returns `v` encoded as XML within a SOAP envelope.
+func marshalSOAP(v interface{}) ([]byte, error) {
+	env := soapEnvelope{NS: soapEnvelopeNS}
+	env.Body.Operation.NS = soapServiceNS
+	env.Body.Operation.Content = v
+	data, err := xml.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// decodeSOAP decodes the content of the SOAP envelope read from `r` into `v`, returning
+// a SOAPFault when the envelope contains one.
+func decodeSOAP(r io.Reader, v interface{}) error {
+	dec := xml.NewDecoder(r)
+	// The content is the first element within the operation (eg. doGlobalAddressResponse)
+	// element of the body, so skip to the third level of nesting.
+	depth := 0
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		} else if err != nil {
+			return err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			if depth == 2 && t.Name.Local == "Fault" {
+				var f SOAPFault
+				if err := dec.DecodeElement(&f, &t); err != nil {
+					return err
+				}
+				return f
+			}
+			if depth == 3 {
+				return dec.DecodeElement(v, &t)
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// newSOAPQuery returns a new SOAP request for the single address of the query params `qs`,
+// as the SOAP service has no equivalent of GET requests.
+func (c Client) newSOAPQuery(ctx context.Context, urlStr string, qs url.Values) (*http.Request, error) {
+	if ref := qs.Get("t"); ref != "" {
+		ctx = ContextWithTransmissionReference(ctx, ref)
+	}
+	body := newBatchRequest([]AddressRequest{requestFromValues(qs)})
+	body.Options, body.Columns = qs.Get("opt"), qs.Get("cols")
+	return c.newPost(ctx, urlStr, body, FormatSOAP)
+}
@@ -0,0 +1,153 @@
+package melissa
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// QueryBatchFunc is like QueryBatch, but streams each returned record to `fn` as it's decoded
+// instead of collecting them within Response.Records, so large batches aren't held in memory.
+// Returning an error from `fn` aborts the request and the error is returned.
+// Each record is audited, as configured by WithAuditStore, before it's passed to `fn`.
+func (c Client) QueryBatchFunc(ctx context.Context, records []AddressRequest, fn func(Record) error, opts ...CallOption) (Response, error) {
+	body := newBatchRequest(c.sanitize(records))
+	req, err := c.newBatch(ContextWithCallOptions(ctx, opts...), body)
+	if err != nil {
+		return Response{}, err
+	}
+	r := Response{each: fn}
+	if c.auditStore != nil {
+		ref, inputs := TransmissionReference(req.Context()), inputsByID(body.Records)
+		r.each = func(rec Record) error {
+			if err := c.audit(req.Context(), ref, inputs, []Record{rec}); err != nil {
+				return err
+			}
+			return fn(rec)
+		}
+	}
+	raw, err := c.do(req, &r)
+	r.each = nil
+	if r.TransmissionReference == "" {
+		r.TransmissionReference = TransmissionReference(req.Context())
+	}
+	if c.rawCapture {
+		r.Raw = raw
+	}
+	return r, err
+}
+
+// decodeStream decodes the response, in the given format, from `rd` token by token,
+// passing each record to r.each.
+func (r *Response) decodeStream(rd io.Reader, f Format) error {
+	if f == FormatXML || f == FormatSOAP {
+		return r.decodeStreamXML(rd)
+	}
+	dec := json.NewDecoder(rd)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	// Collect the remaining (small) fields to decode once the object is complete.
+	rest := map[string]json.RawMessage{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := t.(string)
+		if key != "Records" {
+			var v json.RawMessage
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+			rest[key] = v
+			continue
+		}
+
+		if t, err = dec.Token(); err != nil {
+			return err
+		} else if t == nil {
+			continue
+		} else if d, ok := t.(json.Delim); !ok || d != '[' {
+			return fmt.Errorf("unexpected token, %v, for Records", t)
+		}
+		for dec.More() {
+			var rec Record
+			if err := dec.Decode(&rec); err != nil {
+				return err
+			}
+			if err := r.each(rec); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, r)
+}
+
+// expectDelim reads the next token from `dec`, returning an error if it isn't `delim`.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("unexpected token, %v, expected %v", t, delim)
+	}
+	return nil
+}
+
+// decodeStreamXML decodes the XML response from `rd` token by token, passing each record to r.each.
+func (r *Response) decodeStreamXML(rd io.Reader) error {
+	dec := xml.NewDecoder(rd)
+	var field string
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "ResponseRecord" {
+				field = t.Name.Local
+				continue
+			}
+			var rec Record
+			if err := dec.DecodeElement(&rec, &t); err != nil {
+				return err
+			}
+			if err := r.each(rec); err != nil {
+				return err
+			}
+		case xml.CharData:
+			switch field {
+			case "TotalRecords":
+				r.TotalRecords += string(t)
+			case "TransmissionReference":
+				r.TransmissionReference += string(t)
+			case "TransmissionResults":
+				r.TransmissionResults += string(t)
+			case "Version":
+				r.Version += string(t)
+			}
+		case xml.EndElement:
+			field = ""
+		}
+	}
+}
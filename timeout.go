@@ -0,0 +1,64 @@
+package melissa
+
+import (
+	"context"
+	"time"
+)
+
+// CallOption configures a single call, overriding the client's configuration.
+// Options are passed to the Query methods, or attached to the context of any call
+// (eg. Verify) using ContextWithCallOptions.
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	timeout *time.Duration
+	costs   []*CostTracker
+	premium *PremiumOptions
+}
+
+type callKey struct{}
+
+// WithRequestTimeout limits each call, including any retries and every chunk of a batch,
+// to `d`, independently of the timeout of the underlying HTTP client.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithTimeout limits the call, including any retries and every chunk of a batch, to `d`,
+// overriding WithRequestTimeout. A zero `d` disables the timeout.
+func WithTimeout(d time.Duration) CallOption {
+	return func(cfg *callConfig) {
+		cfg.timeout = &d
+	}
+}
+
+// ContextWithCallOptions returns a copy of `ctx` applying `opts` to calls made with it,
+// in addition to any options already attached to `ctx`. This allows options to be given
+// to calls not accepting them directly, such as those made through a Verifier.
+func ContextWithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	cfg, _ := ctx.Value(callKey{}).(callConfig)
+	cfg.costs = append([]*CostTracker(nil), cfg.costs...)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return context.WithValue(ctx, callKey{}, cfg)
+}
+
+// hasCallOptions returns whether `ctx` holds call options.
+func hasCallOptions(ctx context.Context) bool {
+	_, ok := ctx.Value(callKey{}).(callConfig)
+	return ok
+}
+
+// callTimeout returns the timeout of the call made using `ctx`.
+func (c Client) callTimeout(ctx context.Context) time.Duration {
+	if cfg, ok := ctx.Value(callKey{}).(callConfig); ok && cfg.timeout != nil {
+		return *cfg.timeout
+	}
+	return c.timeout
+}
@@ -0,0 +1,76 @@
+package melissa
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// UnmarshalJSON implements json.Unmarshaler, tolerating numbers and booleans given for
+// string fields and capturing unknown fields within Extras, so that additions to
+// Melissa Data's schema don't fail the response.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	return unmarshalTolerant(data, reflect.ValueOf(r).Elem(), &r.Extras)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, like Response.UnmarshalJSON.
+func (r *Record) UnmarshalJSON(data []byte) error {
+	return unmarshalTolerant(data, reflect.ValueOf(r).Elem(), &r.Extras)
+}
+
+// unmarshalTolerant unmarshals the JSON object `data` into the struct `v`, matching field
+// names case-insensitively. Unknown fields are stored within `extras`.
+func unmarshalTolerant(data []byte, v reflect.Value, extras *map[string]json.RawMessage) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return err
+	}
+
+	t := v.Type()
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Name
+		if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" || f.PkgPath != "" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		index[strings.ToLower(name)] = i
+	}
+
+	for k, raw := range fields {
+		i, ok := index[strings.ToLower(k)]
+		if !ok {
+			if *extras == nil {
+				*extras = map[string]json.RawMessage{}
+			}
+			(*extras)[k] = raw
+			continue
+		}
+		f := v.Field(i)
+		if f.Kind() != reflect.String {
+			if err := json.Unmarshal(raw, f.Addr().Interface()); err != nil {
+				return err
+			}
+			continue
+		}
+		f.SetString(flexibleString(raw))
+	}
+	return nil
+}
+
+// flexibleString returns the JSON value `raw` as a string: strings are unquoted, null is
+// empty, and any other value is its JSON text (eg. 12.5 is "12.5").
+func flexibleString(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	if string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
@@ -0,0 +1,73 @@
+package melissa
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// BenchmarkTransport compares the connections opened, and time taken, by a pool of 32
+// workers querying concurrently using Go's default transport settings and DefaultTransportConfig.
+func BenchmarkTransport(b *testing.B) {
+	http1 := DefaultTransportConfig
+	http1.DisableHTTP2 = true
+	configs := []struct {
+		name string
+		cfg  TransportConfig
+	}{
+		// http.DefaultTransport's settings, over HTTP/1.1 where the idle limits apply.
+		{"HTTP1/HTTPDefault", TransportConfig{MaxIdleConns: 100, MaxIdleConnsPerHost: http.DefaultMaxIdleConnsPerHost, IdleConnTimeout: 90 * time.Second, DisableHTTP2: true}},
+		{"HTTP1/DefaultTransportConfig", http1},
+		{"HTTP2/DefaultTransportConfig", DefaultTransportConfig},
+	}
+	for _, tc := range configs {
+		b.Run(tc.name, func(b *testing.B) {
+			var conns int64
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"Records":[{"RecordID":"1","Results":"AV24"}]}`))
+			}))
+			srv.Config.ConnState = func(_ net.Conn, s http.ConnState) {
+				if s == http.StateNew {
+					atomic.AddInt64(&conns, 1)
+				}
+			}
+			// Connections closed by the client mid-handshake aren't of interest.
+			srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+			srv.StartTLS()
+			defer srv.Close()
+
+			tlsConfig := srv.Client().Transport.(*http.Transport).TLSClientConfig
+			c := NewClient("key", WithURL(srv.URL), WithTLSConfig(tlsConfig), WithTransportConfig(tc.cfg))
+			r := AddressRequest{AddressLine1: "22382 Avenida Empresa", Country: "US"}
+
+			// Requests arrive one at a time, as from a queue, to be sent by a pool of workers.
+			reqs := make(chan struct{})
+			var wg sync.WaitGroup
+			b.ResetTimer()
+			for i := 0; i < 32; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range reqs {
+						if _, err := c.QueryContext(context.Background(), r.Values()); err != nil {
+							b.Error(err)
+						}
+					}
+				}()
+			}
+			for i := 0; i < b.N; i++ {
+				reqs <- struct{}{}
+			}
+			close(reqs)
+			wg.Wait()
+			b.ReportMetric(float64(atomic.LoadInt64(&conns)), "conns")
+		})
+	}
+}
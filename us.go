@@ -0,0 +1,43 @@
+package melissa
+
+// USDetails are the delivery point details returned for US addresses.
+type USDetails struct {
+	// Plus4 is the ZIP+4 add-on code.
+	Plus4 string
+	// DeliveryPointCode and DeliveryPointCheckDigit complete the 12-digit delivery point barcode.
+	DeliveryPointCode       string
+	DeliveryPointCheckDigit string
+	CarrierRoute            string
+	// CMRA is set when the address is a commercial mail receiving agency (eg. a private mailbox).
+	CMRA bool
+	// DPVFootnotes are the delivery point validation footnotes (eg. "AABB").
+	DPVFootnotes string
+	// Residential is set when the address is residential, by the delivery indicator (RBDI).
+	Residential bool
+}
+
+// USDetails returns the delivery point details of the record, and whether any were
+// returned, which Melissa Data only does for US addresses.
+func (r Record) USDetails() (USDetails, bool) {
+	d := USDetails{
+		Plus4:                   r.Plus4,
+		DeliveryPointCode:       r.DeliveryPointCode,
+		DeliveryPointCheckDigit: r.DeliveryPointCheckDigit,
+		CarrierRoute:            r.CarrierRoute,
+		CMRA:                    r.CMRA == "Y",
+		DPVFootnotes:            r.DPVFootnotes,
+		Residential:             r.DeliveryIndicator == "R",
+	}
+	ok := r.Plus4 != "" || r.DeliveryPointCode != "" || r.CarrierRoute != "" || r.CMRA != "" ||
+		r.DPVFootnotes != "" || r.DeliveryIndicator != ""
+	return d, ok
+}
+
+// Barcode returns the 12-digit delivery point barcode (the 5-digit `zip`, Plus4, delivery
+// point code and check digit), or an empty string when any part is missing.
+func (d USDetails) Barcode(zip string) string {
+	if len(zip) < 5 || d.Plus4 == "" || d.DeliveryPointCode == "" || d.DeliveryPointCheckDigit == "" {
+		return ""
+	}
+	return zip[:5] + d.Plus4 + d.DeliveryPointCode + d.DeliveryPointCheckDigit
+}
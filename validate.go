@@ -0,0 +1,101 @@
+package melissa
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juztin/melissa/countries"
+)
+
+// ValidationError is a field of an AddressRequest which failed validation.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// ValidationErrors are all the validation failures of an AddressRequest.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ve := range e {
+		msgs[i] = ve.Error()
+	}
+	return "invalid address, " + strings.Join(msgs, ", ")
+}
+
+// countryRule are the validation rules of a country.
+type countryRule struct {
+	// postal is the format of postal codes.
+	postal *regexp.Regexp
+	// locality requires either a postal code, or both a locality and administrative area.
+	locality bool
+}
+
+// countryRules are the validation rules by ISO 3166-1 alpha-2 code.
+var countryRules = map[string]countryRule{
+	"US": {regexp.MustCompile(`^\d{5}(-?\d{4})?$`), true},
+	"CA": {regexp.MustCompile(`(?i)^[A-Z]\d[A-Z] ?\d[A-Z]\d$`), true},
+	"GB": {regexp.MustCompile(`(?i)^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`), false},
+	"AU": {regexp.MustCompile(`^\d{4}$`), false},
+	"NL": {regexp.MustCompile(`(?i)^\d{4} ?[A-Z]{2}$`), false},
+	"DE": {regexp.MustCompile(`^\d{5}$`), false},
+	"FR": {regexp.MustCompile(`^\d{5}$`), false},
+	"ES": {regexp.MustCompile(`^\d{5}$`), false},
+	"IT": {regexp.MustCompile(`^\d{5}$`), false},
+	"MX": {regexp.MustCompile(`^\d{5}$`), false},
+	"BR": {regexp.MustCompile(`^\d{5}-?\d{3}$`), false},
+	"JP": {regexp.MustCompile(`^\d{3}-?\d{4}$`), false},
+	"IN": {regexp.MustCompile(`^\d{6}$`), false},
+}
+
+// WithValidation validates addresses given to Verify, returning ValidationErrors
+// without making a request when they're invalid.
+func WithValidation() Option {
+	return func(c *Client) {
+		c.validate = true
+	}
+}
+
+// prepare returns the single address `r` sanitized, and validated when configured.
+func (c Client) prepare(r AddressRequest) (AddressRequest, error) {
+	r = c.sanitize([]AddressRequest{r})[0]
+	if c.validate {
+		return r, r.Validate()
+	}
+	return r, nil
+}
+
+// Validate checks the address against the rules of its country, such as the format of its
+// postal code, returning ValidationErrors describing every problem found. Countries
+// without rules only require an address line.
+func (r AddressRequest) Validate() error {
+	var errs ValidationErrors
+	if strings.TrimSpace(r.AddressLine1) == "" {
+		errs = append(errs, ValidationError{"AddressLine1", "required"})
+	}
+	c, known := countries.Lookup(r.Country)
+	if r.Country != "" && !known {
+		errs = append(errs, ValidationError{"Country", "unknown country " + r.Country})
+	}
+
+	ctry := c.Alpha2
+	rule, ok := countryRules[ctry]
+	postal := strings.TrimSpace(r.PostalCode)
+	switch {
+	case !ok:
+	case postal != "" && !rule.postal.MatchString(postal):
+		errs = append(errs, ValidationError{"PostalCode", "invalid format for " + ctry})
+	case postal == "" && rule.locality && (r.Locality == "" || r.AdministrativeArea == ""):
+		errs = append(errs, ValidationError{"PostalCode", "required without Locality and AdministrativeArea"})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
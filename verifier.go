@@ -0,0 +1,88 @@
+package melissa
+
+import (
+	"context"
+	"errors"
+)
+
+// Verifier verifies addresses.
+// Client implements Verifier, allowing consumers to substitute fakes or alternative providers.
+// Call options (eg. WithTimeout) are attached to the context using ContextWithCallOptions.
+type Verifier interface {
+	Verify(ctx context.Context, r AddressRequest) (Result, error)
+	VerifyBatch(ctx context.Context, rs []AddressRequest) ([]Result, error)
+}
+
+var _ Verifier = Client{}
+
+// Verify verifies the single address `r`, configured by any call options attached to `ctx`.
+// Calls with call options aren't coalesced, as they may be configured differently.
+func (c Client) Verify(ctx context.Context, r AddressRequest) (Result, error) {
+	r, err := c.prepare(r)
+	if err != nil {
+		return Result{}, err
+	}
+	if c.coalescer == nil || hasCallOptions(ctx) {
+		return c.verify(ctx, r)
+	}
+	return c.coalescer.do(ctx, r.Values().Encode(), func() (Result, error) {
+		return c.verify(ctx, r)
+	})
+}
+
+// verify verifies the single, prepared, address `r`.
+func (c Client) verify(ctx context.Context, r AddressRequest) (Result, error) {
+	resp, err := c.QueryContext(ctx, r.Values())
+	if err != nil {
+		return Result{}, err
+	}
+	if err = resp.Err(); err != nil {
+		return Result{}, err
+	}
+	if len(resp.Records) == 0 {
+		return Result{}, ErrNoRecords
+	}
+	res := NewResult(resp.Records[0])
+	for _, rec := range resp.Records[1:] {
+		res.Candidates = append(res.Candidates, NewResult(rec))
+	}
+	results := []Result{res}
+	if err = c.process(ctx, results); err != nil {
+		return Result{}, err
+	}
+	return results[0], nil
+}
+
+// VerifyBatch verifies all of the given addresses, returning a result for each returned record.
+// Suggested records sharing a RecordID are returned as Candidates of the first.
+// Batches larger than MaxRecords are split as described by QueryBatch, and are configured
+// by any call options attached to `ctx`.
+// When chunks fail, the results of the successful chunks are returned along with ChunkErrors.
+// When some records fail, the results of the others are returned along with a *PartialError.
+func (c Client) VerifyBatch(ctx context.Context, rs []AddressRequest) ([]Result, error) {
+	rs = prepareRecords(c.sanitize(rs))
+	resp, cerr := c.queryRecords(ctx, rs)
+	var ce ChunkErrors
+	if cerr != nil && !errors.As(cerr, &ce) {
+		return nil, cerr
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	resp, perr := partialError(resp)
+	results := newResults(resp.Records)
+	if err := c.process(ctx, results); err != nil {
+		return nil, err
+	}
+	if cerr != nil {
+		return results, cerr
+	}
+	return results, perr
+}
+
+// QueryFreeForm verifies the single-line, unstructured `address` (eg.
+// "1600 Pennsylvania Ave NW, Washington DC 20500"), leaving Melissa Data to parse it
+// into its components. The `country` may be empty when the address includes it.
+func (c Client) QueryFreeForm(ctx context.Context, address, country string) (Result, error) {
+	return c.Verify(ctx, AddressRequest{AddressLine1: address, Country: country})
+}
@@ -0,0 +1,35 @@
+package melissa
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// ServiceInfo is the version information of the GlobalAddress service.
+type ServiceInfo struct {
+	Version      string
+	BuildNumber  string
+	DatabaseDate string
+}
+
+// ServiceInfo returns the version of the GlobalAddress service from its getVersion endpoint,
+// to detect upstream API changes.
+func (c Client) ServiceInfo(ctx context.Context) (ServiceInfo, error) {
+	var info ServiceInfo
+	err := c.Get(ctx, versionURL(c.urlStr), url.Values{}, &info)
+	return info, err
+}
+
+// versionURL returns the getVersion endpoint alongside the doGlobalAddress endpoint `urlStr`.
+func versionURL(urlStr string) string {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return urlStr
+	}
+	if i := strings.LastIndex(u.Path, "/"); i >= 0 && strings.HasPrefix(u.Path[i+1:], "do") {
+		u.Path = u.Path[:i]
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/getVersion"
+	return u.String()
+}